@@ -0,0 +1,145 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+locals {
+  # Job properties may be provided as JSON strings or as objects
+  ecs_properties       = try(jsondecode(var.ecs_properties), var.ecs_properties)
+  container_properties = try(jsondecode(var.container_properties), var.container_properties)
+
+  # Container properties describe both the task and its single container, which is named after the job definition
+  container_task_fields = ["executionRoleArn", "jobRoleArn", "fargatePlatformConfiguration", "networkConfiguration", "runtimePlatform", "ephemeralStorage", "volumes"]
+
+  task_properties = local.container_properties != null ? merge(
+    { for key, value in local.container_properties : key => value if contains(["executionRoleArn", "networkConfiguration", "runtimePlatform", "ephemeralStorage", "volumes"], key) && value != null },
+    try(local.container_properties.jobRoleArn, null) != null ? { taskRoleArn = local.container_properties.jobRoleArn } : {},
+    try(local.container_properties.fargatePlatformConfiguration.platformVersion, null) != null ? { platformVersion = local.container_properties.fargatePlatformConfiguration.platformVersion } : {},
+    {
+      containers = [
+        merge(
+          { for key, value in local.container_properties : key => value if !contains(local.container_task_fields, key) && value != null },
+          {
+            name      = var.name
+            essential = true
+          },
+        )
+      ]
+    },
+  ) : try(local.ecs_properties.taskProperties[0], null)
+
+  # Batch task containers support a subset of the ECS container definition fields
+  batch_container_fields = [
+    "command",
+    "dependsOn",
+    "environment",
+    "essential",
+    "firelensConfiguration",
+    "image",
+    "linuxParameters",
+    "logConfiguration",
+    "mountPoints",
+    "name",
+    "privileged",
+    "readonlyRootFilesystem",
+    "repositoryCredentials",
+    "resourceRequirements",
+    "secrets",
+    "ulimits",
+    "user",
+  ]
+}
+
+module "dd_containers" {
+  source = "../ecs_fargate_containers"
+
+  dd_api_key                = var.dd_api_key
+  dd_api_key_secret         = var.dd_api_key_secret
+  dd_api_key_ssm_parameter  = var.dd_api_key_ssm_parameter
+  dd_registry               = var.dd_registry
+  dd_image_version          = var.dd_image_version
+  dd_image_digest           = var.dd_image_digest
+  dd_repository_credentials = var.dd_repository_credentials
+  dd_cpu                    = var.dd_cpu
+  dd_memory_limit_mib       = var.dd_memory_limit_mib
+  dd_site                   = var.dd_site
+  dd_environment            = var.dd_environment
+  dd_secrets                = var.dd_secrets
+  dd_tags                   = var.dd_tags
+  dd_cluster_name           = var.dd_cluster_name
+  dd_service                = var.dd_service
+  dd_env                    = var.dd_env
+  dd_version                = var.dd_version
+  dd_dogstatsd              = var.dd_dogstatsd
+  dd_apm                    = var.dd_apm
+  dd_log_collection         = var.dd_log_collection
+
+  # Jobs complete when their essential containers exit, so the Datadog Agent
+  # must not be essential and is stopped along with the job. Batch does not
+  # support container health checks, so containers cannot wait for the Agent
+  dd_essential                     = false
+  dd_is_datadog_dependency_enabled = false
+
+  container_definitions = try(local.task_properties.containers, [])
+  runtime_platform = try(local.task_properties.runtimePlatform, null) != null ? {
+    cpu_architecture        = try(local.task_properties.runtimePlatform.cpuArchitecture, "X86_64")
+    operating_system_family = try(local.task_properties.runtimePlatform.operatingSystemFamily, "LINUX")
+  } : null
+}
+
+locals {
+  tags = module.dd_containers.tags
+
+  # Convert the rendered ECS container definitions to Batch task containers
+  dd_containers = jsondecode(module.dd_containers.container_definitions)
+
+  batch_containers = [
+    for container in local.dd_containers : merge(
+      { for key, value in container : key => value if contains(local.batch_container_fields, key) && value != null },
+      # `HEALTHY` dependencies are not supported since Batch does not run container health checks
+      {
+        environment = [for env in try(container.environment, []) : env if try(env.name, null) != null]
+        dependsOn = [
+          for dependency in lookup(container, "dependsOn", []) : merge(dependency, {
+            condition = dependency.condition == "HEALTHY" ? "START" : dependency.condition
+          })
+        ]
+      },
+      # Only convert the Datadog containers CPU and memory when not already defined by resource requirements.
+      # Batch only supports hard memory limits, so memory reservations are converted to limits
+      try(container.resourceRequirements, null) == null && (try(container.cpu, null) != null || try(container.memoryReservation, null) != null || try(container.memory, null) != null) ? {
+        resourceRequirements = concat(
+          try(container.cpu, null) != null ? [
+            {
+              type  = "VCPU"
+              value = tostring(container.cpu / 1024)
+            }
+          ] : [],
+          try(coalesce(try(container.memory, null), try(container.memoryReservation, null)), null) != null ? [
+            {
+              type  = "MEMORY"
+              value = tostring(coalesce(try(container.memory, null), try(container.memoryReservation, null)))
+            }
+          ] : [],
+        )
+      } : {},
+    )
+  ]
+
+  # Datadog volumes are task storage bind mounts
+  batch_volumes = concat(
+    try(local.task_properties.volumes, []),
+    [for volume in module.dd_containers.volumes : { name = volume.name }],
+  )
+
+  batch_task_properties = merge(
+    # Null when no job properties are provided, which is reported by the job definition precondition
+    local.task_properties == null ? null : { for key, value in local.task_properties : key => value if key != "containers" && key != "volumes" },
+    {
+      containers       = local.batch_containers
+      volumes          = local.batch_volumes
+      executionRoleArn = module.dd_task_roles.execution_role_arn
+      taskRoleArn      = module.dd_task_roles.task_role_arn
+    },
+  )
+}
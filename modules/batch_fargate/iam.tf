@@ -0,0 +1,34 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# ==============================
+# Task Execution Role and Job Role
+# ==============================
+
+# Jobs on Fargate always require a *task execution role*.
+# Will create one unless provided by the user or in the job
+# properties, and add the permissions to access the Datadog
+# secrets and SSM parameters when required. Will create or
+# edit the *job role* always in order to add permissions
+# for the ecs_fargate check
+
+locals {
+  user_execution_role = var.execution_role != null ? var.execution_role : try(local.task_properties.executionRoleArn, null) != null ? { arn = local.task_properties.executionRoleArn } : null
+  user_job_role       = var.job_role != null ? var.job_role : try(local.task_properties.taskRoleArn, null) != null ? { arn = local.task_properties.taskRoleArn } : null
+}
+
+module "dd_task_roles" {
+  source = "../ecs_task_roles"
+
+  name = var.name
+
+  execution_role                    = local.user_execution_role
+  is_execution_role_required        = true
+  is_execution_role_policy_required = module.dd_containers.is_execution_role_policy_required
+  execution_role_policy_statements  = module.dd_containers.execution_role_policy_statements
+
+  task_role                   = local.user_job_role
+  task_role_policy_statements = module.dd_containers.task_role_policy_statements
+}
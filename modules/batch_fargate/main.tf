@@ -0,0 +1,53 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Batch Job Definition
+################################################################################
+
+resource "aws_batch_job_definition" "this" {
+  name                  = var.name
+  type                  = "container"
+  platform_capabilities = ["FARGATE"]
+
+  ecs_properties = jsonencode({
+    taskProperties = [local.batch_task_properties]
+  })
+
+  propagate_tags = var.propagate_tags
+
+  dynamic "retry_strategy" {
+    for_each = var.retry_strategy != null ? [var.retry_strategy] : []
+
+    content {
+      attempts = retry_strategy.value.attempts
+    }
+  }
+
+  dynamic "timeout" {
+    for_each = var.timeout != null ? [var.timeout] : []
+
+    content {
+      attempt_duration_seconds = timeout.value.attempt_duration_seconds
+    }
+  }
+
+  tags = merge(
+    var.tags,
+    local.tags,
+  )
+
+  depends_on = [
+    module.dd_task_roles,
+  ]
+
+  lifecycle {
+    # Must provide exactly one of the two job properties options
+    precondition {
+      condition     = (var.ecs_properties == null) != (var.container_properties == null)
+      error_message = "You must provide exactly one of `ecs_properties` or `container_properties`."
+    }
+  }
+}
@@ -0,0 +1,44 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+output "arn" {
+  description = "ARN of the job definition, including the revision."
+  value       = aws_batch_job_definition.this.arn
+}
+
+output "arn_prefix" {
+  description = "ARN of the job definition without the revision."
+  value       = aws_batch_job_definition.this.arn_prefix
+}
+
+output "ecs_properties" {
+  description = "The job ECS properties, including the Datadog containers, provided as a single valid JSON document."
+  value       = aws_batch_job_definition.this.ecs_properties
+}
+
+output "execution_role_arn" {
+  description = "ARN of the task execution role."
+  value       = module.dd_task_roles.execution_role_arn
+}
+
+output "job_role_arn" {
+  description = "ARN of the job role."
+  value       = module.dd_task_roles.task_role_arn
+}
+
+output "name" {
+  description = "Name of the job definition."
+  value       = aws_batch_job_definition.this.name
+}
+
+output "revision" {
+  description = "Revision of the job definition."
+  value       = aws_batch_job_definition.this.revision
+}
+
+output "tags" {
+  description = "Key-value map of resource tags."
+  value       = aws_batch_job_definition.this.tags
+}
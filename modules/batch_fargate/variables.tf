@@ -0,0 +1,417 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Datadog AWS Batch Fargate Configuration
+################################################################################
+
+variable "dd_api_key" {
+  description = "Datadog API Key"
+  type        = string
+  default     = null
+}
+
+variable "dd_api_key_secret" {
+  description = "Datadog API Key Secret ARN. Provide `kms_key_arn` when the secret is encrypted with a customer managed KMS key"
+  type = object({
+    arn         = string
+    kms_key_arn = optional(string)
+  })
+  default = null
+  validation {
+    condition     = var.dd_api_key_secret == null || try(var.dd_api_key_secret.arn != null, false)
+    error_message = "If 'dd_api_key_secret' is set, 'arn' must be a non-null string."
+  }
+  validation {
+    condition     = try(var.dd_api_key_secret.kms_key_arn == null, true) || try(can(regex("^arn:[^:]+:kms:[^:]+:[0-9]{12}:key/", var.dd_api_key_secret.kms_key_arn)), false)
+    error_message = "If 'dd_api_key_secret.kms_key_arn' is set, it must be a valid KMS key ARN."
+  }
+}
+
+variable "dd_api_key_ssm_parameter" {
+  description = "Datadog API Key SSM Parameter Store parameter ARN. Provide `kms_key_arn` when the SecureString parameter is encrypted with a customer managed KMS key"
+  type = object({
+    arn         = string
+    kms_key_arn = optional(string)
+  })
+  default = null
+  validation {
+    condition     = var.dd_api_key_ssm_parameter == null || try(can(regex("^arn:[^:]+:ssm:[^:]+:[0-9]{12}:parameter/", var.dd_api_key_ssm_parameter.arn)), false)
+    error_message = "If 'dd_api_key_ssm_parameter' is set, 'arn' must be a valid SSM parameter ARN."
+  }
+  validation {
+    condition     = try(var.dd_api_key_ssm_parameter.kms_key_arn == null, true) || try(can(regex("^arn:[^:]+:kms:[^:]+:[0-9]{12}:key/", var.dd_api_key_ssm_parameter.kms_key_arn)), false)
+    error_message = "If 'dd_api_key_ssm_parameter.kms_key_arn' is set, it must be a valid KMS key ARN."
+  }
+}
+
+variable "dd_registry" {
+  description = "Datadog Agent image registry"
+  type        = string
+  default     = "public.ecr.aws/datadog/agent"
+  nullable    = false
+}
+
+variable "dd_image_version" {
+  description = "Datadog Agent image version"
+  type        = string
+  default     = "latest"
+  nullable    = false
+}
+
+variable "dd_image_digest" {
+  description = "Datadog Agent image digest (for example, `sha256:...`). Takes precedence over `dd_image_version` when set"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.dd_image_digest == null || can(regex("^sha256:[a-f0-9]{64}$", var.dd_image_digest))
+    error_message = "If 'dd_image_digest' is set, it must be a `sha256:` digest."
+  }
+}
+
+variable "dd_repository_credentials" {
+  description = "Datadog Agent private registry credentials. `credentials_parameter` is the ARN of the Secrets Manager secret containing the registry username and password"
+  type = object({
+    credentials_parameter = string
+  })
+  default = null
+  validation {
+    condition     = var.dd_repository_credentials == null || try(can(regex("^arn:[^:]+:secretsmanager:[^:]+:[0-9]{12}:secret:", var.dd_repository_credentials.credentials_parameter)), false)
+    error_message = "If 'dd_repository_credentials' is set, 'credentials_parameter' must be a valid Secrets Manager secret ARN."
+  }
+}
+
+variable "dd_cpu" {
+  description = "Datadog Agent container CPU units, converted to the container `VCPU` resource requirement"
+  type        = number
+  default     = null
+}
+
+variable "dd_memory_limit_mib" {
+  description = "Datadog Agent container memory limit in MiB, converted to the container `MEMORY` resource requirement"
+  type        = number
+  default     = null
+}
+
+variable "dd_site" {
+  description = "Datadog Site"
+  type        = string
+  default     = "datadoghq.com"
+}
+
+variable "dd_environment" {
+  description = "Datadog Agent container environment variables. Highest precedence and overwrites other environment variables defined by the module. For example, `dd_environment = [ { name = 'DD_VAR', value = 'DD_VAL' } ]`"
+  type        = list(map(string))
+  default     = [{}]
+  nullable    = false
+}
+
+variable "dd_secrets" {
+  description = "Datadog Agent container secrets, mapping environment variable names to Secrets Manager secret or SSM parameter ARNs. Overwrites `dd_environment` variables with the same names. For example, `dd_secrets = { DD_APP_KEY = 'arn:aws:secretsmanager:us-east-1:123456789012:secret:dd-app-key' }`"
+  type        = map(string)
+  default     = {}
+  nullable    = false
+  validation {
+    condition     = alltrue([for arn in values(var.dd_secrets) : can(regex("^arn:[^:]+:(secretsmanager:[^:]+:[0-9]{12}:secret:|ssm:[^:]+:[0-9]{12}:parameter/)", arn))])
+    error_message = "All 'dd_secrets' values must be valid Secrets Manager secret or SSM parameter ARNs."
+  }
+  validation {
+    condition     = !contains(keys(var.dd_secrets), "DD_API_KEY")
+    error_message = "The Datadog API key cannot be set in 'dd_secrets'. Please use `dd_api_key_secret` or `dd_api_key_ssm_parameter` instead."
+  }
+}
+
+variable "dd_tags" {
+  description = "Datadog Agent global tags (eg. `key1:value1, key2:value2`)"
+  type        = string
+  default     = null
+}
+
+variable "dd_cluster_name" {
+  description = "Datadog cluster name"
+  type        = string
+  default     = null
+}
+
+variable "dd_service" {
+  description = "The task service name. Used for tagging (UST)"
+  type        = string
+  default     = null
+}
+
+variable "dd_env" {
+  description = "The task environment name. Used for tagging (UST)"
+  type        = string
+  default     = null
+}
+
+variable "dd_version" {
+  description = "The task version name. Used for tagging (UST)"
+  type        = string
+  default     = null
+}
+
+variable "dd_dogstatsd" {
+  description = "Configuration for Datadog DogStatsD"
+  type = object({
+    enabled                  = optional(bool, true)
+    origin_detection_enabled = optional(bool, true)
+    dogstatsd_cardinality    = optional(string, "orchestrator")
+    socket_enabled           = optional(bool, true)
+  })
+  default = {
+    enabled                  = true
+    origin_detection_enabled = true
+    dogstatsd_cardinality    = "orchestrator"
+    socket_enabled           = true
+  }
+  validation {
+    condition     = var.dd_dogstatsd != null
+    error_message = "The Datadog Dogstatsd configuration must be defined."
+  }
+  validation {
+    condition     = try(var.dd_dogstatsd.dogstatsd_cardinality == null, false) || can(contains(["low", "orchestrator", "high"], var.dd_dogstatsd.dogstatsd_cardinality))
+    error_message = "The Datadog Dogstatsd cardinality must be one of 'low', 'orchestrator', 'high', or null."
+  }
+}
+
+variable "dd_apm" {
+  description = "Configuration for Datadog APM"
+  type = object({
+    enabled                       = optional(bool, true)
+    socket_enabled                = optional(bool, true)
+    profiling                     = optional(bool, false)
+    trace_inferred_proxy_services = optional(bool, false)
+    trace_sample_rate             = optional(number)
+    trace_rate_limit              = optional(number)
+    trace_sampling_rules = optional(list(object({
+      sample_rate    = number
+      service        = optional(string)
+      name           = optional(string)
+      resource       = optional(string)
+      tags           = optional(map(string))
+      max_per_second = optional(number)
+    })))
+    dbm_propagation_mode    = optional(string)
+    data_streams_enabled    = optional(bool)
+    logs_injection          = optional(bool)
+    runtime_metrics_enabled = optional(bool)
+  })
+  default = {
+    enabled                       = true
+    socket_enabled                = true
+    profiling                     = false
+    trace_inferred_proxy_services = false
+  }
+  validation {
+    condition     = var.dd_apm != null
+    error_message = "The Datadog APM configuration must be defined."
+  }
+  validation {
+    condition     = try(var.dd_apm.trace_sample_rate == null, false) || try(var.dd_apm.trace_sample_rate >= 0 && var.dd_apm.trace_sample_rate <= 1, false)
+    error_message = "The Datadog APM trace sample rate must be between 0 and 1."
+  }
+  validation {
+    condition     = try(var.dd_apm.trace_rate_limit == null, false) || try(var.dd_apm.trace_rate_limit >= 0, false)
+    error_message = "The Datadog APM trace rate limit must be a non-negative number."
+  }
+  validation {
+    condition     = try(var.dd_apm.trace_sampling_rules == null, false) || try(alltrue([for rule in var.dd_apm.trace_sampling_rules : rule.sample_rate >= 0 && rule.sample_rate <= 1]), false)
+    error_message = "The Datadog APM trace sampling rules sample rate must be between 0 and 1."
+  }
+  validation {
+    condition     = try(var.dd_apm.dbm_propagation_mode == null, false) || try(contains(["disabled", "service", "full"], var.dd_apm.dbm_propagation_mode), false)
+    error_message = "The Datadog APM DBM propagation mode must be one of 'disabled', 'service', 'full', or null."
+  }
+}
+
+variable "dd_log_collection" {
+  description = "Configuration for Datadog Log Collection"
+  type = object({
+    enabled = optional(bool, false)
+    fluentbit_config = optional(object({
+      registry                         = optional(string, "public.ecr.aws/aws-observability/aws-for-fluent-bit")
+      image_version                    = optional(string, "stable")
+      image_digest                     = optional(string)
+      cpu                              = optional(number)
+      memory_limit_mib                 = optional(number)
+      is_log_router_essential          = optional(bool, false)
+      is_log_router_dependency_enabled = optional(bool, false)
+      repository_credentials = optional(object({
+        credentials_parameter = string
+      }))
+      log_router_health_check = optional(object({
+        command      = optional(list(string))
+        interval     = optional(number)
+        retries      = optional(number)
+        start_period = optional(number)
+        timeout      = optional(number)
+        }),
+        {
+          command      = ["CMD-SHELL", "exit 0"]
+          interval     = 5
+          retries      = 3
+          start_period = 15
+          timeout      = 5
+        }
+      )
+      firelens_options = optional(object({
+        config_file_type  = optional(string)
+        config_file_value = optional(string)
+      }))
+      log_driver_configuration = optional(object({
+        host_endpoint = optional(string, "http-intake.logs.datadoghq.com")
+        tls           = optional(bool)
+        compress      = optional(string)
+        service_name  = optional(string)
+        source_name   = optional(string)
+        message_key   = optional(string)
+        }),
+        {
+          host_endpoint = "http-intake.logs.datadoghq.com"
+        }
+      )
+      }),
+      {
+        fluentbit_config = {
+          registry      = "public.ecr.aws/aws-observability/aws-for-fluent-bit"
+          image_version = "stable"
+          log_driver_configuration = {
+            host_endpoint = "http-intake.logs.datadoghq.com"
+          }
+        }
+      }
+    )
+  })
+  default = {
+    enabled = false
+    fluentbit_config = {
+      is_log_router_essential = false
+      log_driver_configuration = {
+        host_endpoint = "http-intake.logs.datadoghq.com"
+      }
+    }
+  }
+  validation {
+    condition     = var.dd_log_collection != null
+    error_message = "The Datadog Log Collection configuration must be defined."
+  }
+  validation {
+    condition     = try(var.dd_log_collection.enabled == false, false) || try(var.dd_log_collection.enabled == true && var.dd_log_collection.fluentbit_config != null, false)
+    error_message = "The Datadog Log Collection fluentbit configuration must be defined."
+  }
+  validation {
+    condition     = try(var.dd_log_collection.enabled == false, false) || try(var.dd_log_collection.enabled == true && var.dd_log_collection.fluentbit_config.log_driver_configuration != null, false)
+    error_message = "The Datadog Log Collection log driver configuration must be defined."
+  }
+  validation {
+    condition     = try(var.dd_log_collection.enabled == false, false) || try(var.dd_log_collection.enabled == true && var.dd_log_collection.fluentbit_config.log_driver_configuration.host_endpoint != null, false)
+    error_message = "The Datadog Log Collection log driver configuration host endpoint must be defined."
+  }
+  validation {
+    condition     = try(var.dd_log_collection.fluentbit_config.image_digest == null, true) || try(can(regex("^sha256:[a-f0-9]{64}$", var.dd_log_collection.fluentbit_config.image_digest)), false)
+    error_message = "If the Datadog Log Collection 'image_digest' is set, it must be a `sha256:` digest."
+  }
+  validation {
+    condition     = try(var.dd_log_collection.fluentbit_config.repository_credentials == null, true) || try(can(regex("^arn:[^:]+:secretsmanager:[^:]+:[0-9]{12}:secret:", var.dd_log_collection.fluentbit_config.repository_credentials.credentials_parameter)), false)
+    error_message = "If the Datadog Log Collection 'repository_credentials' is set, 'credentials_parameter' must be a valid Secrets Manager secret ARN."
+  }
+}
+
+################################################################################
+# Job Definition
+################################################################################
+
+variable "name" {
+  description = "Name of the job definition"
+  type        = string
+}
+
+# Note: typed as `any` since it accepts either a JSON string or an object
+variable "ecs_properties" {
+  description = "A valid [ECS properties](https://docs.aws.amazon.com/batch/latest/APIReference/API_EcsProperties.html) document with a single task, provided either as a JSON string or as an object. Conflicts with `container_properties`"
+  type        = any
+  default     = null
+  validation {
+    condition     = var.ecs_properties == null || try(length(try(jsondecode(var.ecs_properties), var.ecs_properties).taskProperties) == 1, false)
+    error_message = "The `ecs_properties` must define exactly one entry in `taskProperties`."
+  }
+  validation {
+    condition     = var.ecs_properties == null || try(alltrue([for container in try(jsondecode(var.ecs_properties), var.ecs_properties).taskProperties[0].containers : try(container.name != null && container.name != "" && container.image != null && container.image != "", false)]), false)
+    error_message = "Each `ecs_properties` container must define a non-empty `name` and `image`."
+  }
+}
+
+# Note: typed as `any` since it accepts either a JSON string or an object
+variable "container_properties" {
+  description = "A valid [container properties](https://docs.aws.amazon.com/batch/latest/APIReference/API_ContainerProperties.html) document, provided either as a JSON string or as an object. It is converted to `ecs_properties` so that the Datadog containers can run alongside it. Conflicts with `ecs_properties`"
+  type        = any
+  default     = null
+  validation {
+    condition     = var.container_properties == null || try(try(jsondecode(var.container_properties), var.container_properties).image != "", false)
+    error_message = "The `container_properties` must define a non-empty `image`."
+  }
+}
+
+variable "retry_strategy" {
+  description = "Retry strategy of the job. `attempts` must be between 1 and 10"
+  type = object({
+    attempts = number
+  })
+  default = null
+  validation {
+    condition     = var.retry_strategy == null || try(var.retry_strategy.attempts >= 1 && var.retry_strategy.attempts <= 10, false)
+    error_message = "The `retry_strategy` attempts must be between 1 and 10."
+  }
+}
+
+variable "timeout" {
+  description = "Timeout of the job. `attempt_duration_seconds` must be at least 60 seconds"
+  type = object({
+    attempt_duration_seconds = number
+  })
+  default = null
+  validation {
+    condition     = var.timeout == null || try(var.timeout.attempt_duration_seconds >= 60, false)
+    error_message = "The `timeout` attempt duration must be at least 60 seconds."
+  }
+}
+
+variable "propagate_tags" {
+  description = "Whether to propagate the tags from the job definition to the corresponding Amazon ECS task"
+  type        = bool
+  default     = true
+  nullable    = false
+}
+
+variable "execution_role" {
+  description = "ARN of the task execution role that the Amazon ECS container agent and the Docker daemon can assume. Created by the module when not provided"
+  type = object({
+    arn = string
+  })
+  default = null
+  validation {
+    condition     = var.execution_role == null || try(var.execution_role.arn != null, false)
+    error_message = "If 'execution_role' is set, 'arn' must be a non-null string."
+  }
+}
+
+variable "job_role" {
+  description = "ARN of the IAM role that the job containers can assume for AWS permissions. Created by the module when not provided"
+  type = object({
+    arn = string
+  })
+  default = null
+  validation {
+    condition     = var.job_role == null || try(var.job_role.arn != null, false)
+    error_message = "If 'job_role' is set, 'arn' must be a non-null string."
+  }
+}
+
+variable "tags" {
+  description = "A map of additional tags to add to the job definition"
+  type        = map(string)
+  default     = null
+}
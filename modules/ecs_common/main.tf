@@ -0,0 +1,87 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# Version and Install Info
+locals {
+  # Datadog ECS task tags
+  version = "1.0.3"
+
+  install_info_tool              = "terraform"
+  install_info_tool_version      = "terraform-aws-ecs-datadog"
+  install_info_installer_version = local.version
+
+  # AWS Resource Tags
+  tags = {
+    dd_ecs_terraform_module = local.version
+  }
+
+  install_info_env_vars = [
+    {
+      name  = "DD_INSTALL_INFO_TOOL"
+      value = local.install_info_tool
+    },
+    {
+      name  = "DD_INSTALL_INFO_TOOL_VERSION"
+      value = local.install_info_tool_version
+    },
+    {
+      name  = "DD_INSTALL_INFO_INSTALLER_VERSION"
+      value = local.install_info_installer_version
+    },
+  ]
+}
+
+locals {
+  # Datadog Agent image, pinned by digest when provided
+  dd_agent_image = var.dd_image_digest != null ? "${var.dd_registry}@${var.dd_image_digest}" : try("${var.dd_registry}:${var.dd_image_version}", null)
+
+  # The sockets are shared with the application containers through the `dd-sockets` volume
+  is_apm_dsd_volume = var.is_apm_socket_enabled || var.is_dsd_socket_enabled
+
+  apm_dsd_mount = local.is_apm_dsd_volume ? [
+    {
+      containerPath = "/var/run/datadog"
+      sourceVolume  = "dd-sockets"
+      readOnly      = false
+    }
+  ] : []
+
+  apm_socket_var = var.is_apm_socket_enabled ? [
+    {
+      name  = "DD_TRACE_AGENT_URL"
+      value = "unix:///var/run/datadog/apm.socket"
+    }
+  ] : []
+
+  dsd_socket_var = var.is_dsd_socket_enabled ? [
+    {
+      name  = "DD_DOGSTATSD_URL"
+      value = "unix:///var/run/datadog/dsd.socket"
+    }
+  ] : []
+
+  ust_env_vars = concat(
+    var.dd_env != null ? [
+      {
+        name  = "DD_ENV"
+        value = var.dd_env
+      }
+    ] : [],
+    var.dd_service != null ? [
+      {
+        name  = "DD_SERVICE"
+        value = var.dd_service
+      }
+    ] : [],
+    var.dd_version != null ? [
+      {
+        name  = "DD_VERSION"
+        value = var.dd_version
+      }
+    ] : [],
+  )
+
+  ust_docker_labels = { for env in local.ust_env_vars : "com.datadoghq.tags.${lower(trimprefix(env.name, "DD_"))}" => env.value }
+}
@@ -0,0 +1,44 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+output "version" {
+  description = "Version of the Datadog ECS Terraform modules."
+  value       = local.version
+}
+
+output "tags" {
+  description = "Datadog tags to add to the task definition and related resources."
+  value       = local.tags
+}
+
+output "install_info_env_vars" {
+  description = "Install info environment variables of the Datadog Agent container."
+  value       = local.install_info_env_vars
+}
+
+output "dd_agent_image" {
+  description = "Datadog Agent image, pinned by digest when provided. Null when `dd_registry` is not provided."
+  value       = local.dd_agent_image
+}
+
+output "apm_dsd_mount_points" {
+  description = "Mount points of the Datadog sockets volume to add to the application containers."
+  value       = local.apm_dsd_mount
+}
+
+output "apm_dsd_socket_env_vars" {
+  description = "Environment variables configuring the application containers to use the Datadog sockets."
+  value       = concat(local.dsd_socket_var, local.apm_socket_var)
+}
+
+output "ust_env_vars" {
+  description = "Unified Service Tagging environment variables to add to the containers."
+  value       = local.ust_env_vars
+}
+
+output "ust_docker_labels" {
+  description = "Unified Service Tagging docker labels to add to the containers."
+  value       = local.ust_docker_labels
+}
@@ -0,0 +1,58 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Datadog Common Configuration
+################################################################################
+
+variable "dd_registry" {
+  description = "Datadog Agent image registry"
+  type        = string
+  default     = null
+}
+
+variable "dd_image_version" {
+  description = "Datadog Agent image version"
+  type        = string
+  default     = null
+}
+
+variable "dd_image_digest" {
+  description = "Datadog Agent image digest, used instead of `dd_image_version` when provided"
+  type        = string
+  default     = null
+}
+
+variable "dd_service" {
+  description = "The task service name. Used for tagging (UST)"
+  type        = string
+  default     = null
+}
+
+variable "dd_env" {
+  description = "The task environment name. Used for tagging (UST)"
+  type        = string
+  default     = null
+}
+
+variable "dd_version" {
+  description = "The task version name. Used for tagging (UST)"
+  type        = string
+  default     = null
+}
+
+variable "is_apm_socket_enabled" {
+  description = "Whether the application containers send traces to the Datadog Agent through the APM socket"
+  type        = bool
+  default     = false
+  nullable    = false
+}
+
+variable "is_dsd_socket_enabled" {
+  description = "Whether the application containers send custom metrics to the Datadog Agent through the DogStatsD socket"
+  type        = bool
+  default     = false
+  nullable    = false
+}
@@ -0,0 +1,212 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# Version and Install Info
+module "dd_common" {
+  source = "../ecs_common"
+
+  dd_registry      = var.dd_registry
+  dd_image_version = var.dd_image_version
+  dd_image_digest  = var.dd_image_digest
+}
+
+locals {
+  # AWS Resource Tags
+  tags = module.dd_common.tags
+}
+
+locals {
+
+  # Datadog API key reference (Secrets Manager secret or SSM parameter)
+  dd_api_key_value_from = try(var.dd_api_key_secret.arn, var.dd_api_key_ssm_parameter.arn, null)
+
+  dd_agent_image = module.dd_common.dd_agent_image
+
+  # The sockets are shared with the application containers through the host
+  is_apm_socket_enabled = var.dd_apm.enabled && var.dd_apm.socket_enabled
+  is_dsd_socket_enabled = var.dd_dogstatsd.enabled && var.dd_dogstatsd.socket_enabled
+  is_dd_sockets_volume  = local.is_apm_socket_enabled || local.is_dsd_socket_enabled
+
+  # Host volumes required by the Datadog Agent daemon
+  host_volumes = concat(
+    [
+      {
+        name          = "docker_sock"
+        host_path     = "/var/run/docker.sock"
+        containerPath = "/var/run/docker.sock"
+        readOnly      = true
+      },
+      {
+        name          = "proc"
+        host_path     = "/proc/"
+        containerPath = "/host/proc/"
+        readOnly      = true
+      },
+      {
+        name          = "cgroup"
+        host_path     = "/sys/fs/cgroup/"
+        containerPath = "/host/sys/fs/cgroup/"
+        readOnly      = true
+      },
+    ],
+    local.is_dd_sockets_volume ? [
+      {
+        name          = "dd-sockets"
+        host_path     = "/var/run/datadog"
+        containerPath = "/var/run/datadog"
+        readOnly      = false
+      }
+    ] : [],
+    var.dd_log_collection.enabled ? [
+      {
+        name          = "pointdir"
+        host_path     = "/opt/datadog-agent/run"
+        containerPath = "/opt/datadog-agent/run"
+        readOnly      = false
+      },
+      {
+        name          = "containers_root"
+        host_path     = "/var/lib/docker/containers"
+        containerPath = "/var/lib/docker/containers"
+        readOnly      = true
+      },
+    ] : [],
+  )
+
+  # Datadog Agent container environment variables
+  base_env = concat(
+    [
+      {
+        name  = "DD_ECS_TASK_COLLECTION_ENABLED"
+        value = "true"
+      },
+    ],
+    module.dd_common.install_info_env_vars,
+  )
+
+  dynamic_env = [
+    for pair in [
+      { key = "DD_API_KEY", value = var.dd_api_key },
+      { key = "DD_SITE", value = var.dd_site },
+      { key = "DD_TAGS", value = var.dd_tags },
+      { key = "DD_CLUSTER_NAME", value = var.dd_cluster_name },
+      { key = "DD_CHECKS_TAG_CARDINALITY", value = var.dd_checks_cardinality },
+      { key = "DD_DOGSTATSD_TAG_CARDINALITY", value = var.dd_dogstatsd.dogstatsd_cardinality },
+    ] : { name = pair.key, value = pair.value } if pair.value != null
+  ]
+
+  # Application containers send traces and metrics to the host, not to the loopback interface
+  apm_vars = [
+    {
+      name  = "DD_APM_ENABLED"
+      value = tostring(var.dd_apm.enabled)
+    },
+    {
+      name  = "DD_APM_NON_LOCAL_TRAFFIC"
+      value = tostring(var.dd_apm.enabled)
+    },
+  ]
+
+  dsd_vars = concat(
+    [
+      {
+        name  = "DD_USE_DOGSTATSD"
+        value = tostring(var.dd_dogstatsd.enabled)
+      },
+      {
+        name  = "DD_DOGSTATSD_NON_LOCAL_TRAFFIC"
+        value = tostring(var.dd_dogstatsd.enabled)
+      },
+    ],
+    var.dd_dogstatsd.enabled && var.dd_dogstatsd.origin_detection_enabled ? [
+      {
+        name  = "DD_DOGSTATSD_ORIGIN_DETECTION"
+        value = "true"
+      },
+      {
+        name  = "DD_DOGSTATSD_ORIGIN_DETECTION_CLIENT"
+        value = "true"
+      }
+    ] : [],
+  )
+
+  logs_vars = var.dd_log_collection.enabled ? [
+    {
+      name  = "DD_LOGS_ENABLED"
+      value = "true"
+    },
+    {
+      name  = "DD_LOGS_CONFIG_CONTAINER_COLLECT_ALL"
+      value = tostring(var.dd_log_collection.container_collect_all)
+    },
+  ] : []
+
+  dd_agent_secrets = concat(
+    local.dd_api_key_value_from != null ? [
+      {
+        name      = "DD_API_KEY"
+        valueFrom = local.dd_api_key_value_from
+      }
+    ] : [],
+    [for name, value_from in var.dd_secrets : { name = name, valueFrom = value_from }],
+  )
+
+  # Environment variables provided as secrets are removed to avoid duplicates
+  dd_agent_env = [
+    for env in concat(
+      local.base_env,
+      local.dynamic_env,
+      local.apm_vars,
+      local.dsd_vars,
+      local.logs_vars,
+      var.dd_environment,
+    ) : env if !contains(local.dd_agent_secrets[*].name, lookup(env, "name", ""))
+  ]
+
+  # Datadog Agent daemon container definition
+  dd_agent_container = [
+    merge(
+      {
+        name        = "datadog-agent"
+        image       = local.dd_agent_image
+        essential   = true
+        environment = local.dd_agent_env
+        cpu         = var.dd_cpu
+        memory      = var.dd_memory_limit_mib
+        secrets     = local.dd_agent_secrets
+        portMappings = [
+          {
+            containerPort = 8125
+            hostPort      = 8125
+            protocol      = "udp"
+          },
+          {
+            containerPort = 8126
+            hostPort      = 8126
+            protocol      = "tcp"
+          }
+        ]
+        mountPoints = [
+          for volume in local.host_volumes : {
+            sourceVolume  = volume.name
+            containerPath = volume.containerPath
+            readOnly      = volume.readOnly
+          }
+        ]
+        systemControls = []
+        volumesFrom    = []
+      },
+      try(var.dd_health_check.command == null, true) ? {} : {
+        healthCheck = {
+          command     = var.dd_health_check.command
+          interval    = var.dd_health_check.interval
+          timeout     = var.dd_health_check.timeout
+          retries     = var.dd_health_check.retries
+          startPeriod = var.dd_health_check.start_period
+        }
+      }
+    )
+  ]
+}
@@ -0,0 +1,129 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# ==============================
+# Task Execution Role
+# ==============================
+
+# Will create or edit the *task execution role*
+# only if the user provides Datadog secret or SSM parameter
+# ARNs in order to provide permissions to access the secrets
+
+locals {
+  create_dd_secret_perms = var.dd_api_key_secret != null || var.dd_api_key_ssm_parameter != null || length(var.dd_secrets) > 0
+  edit_execution_role    = var.execution_role != null && local.create_dd_secret_perms
+  create_execution_role  = var.execution_role == null && local.create_dd_secret_perms
+
+  dd_value_from_arns = concat(
+    [for source in [var.dd_api_key_secret, var.dd_api_key_ssm_parameter] : source.arn if source != null],
+    values(var.dd_secrets),
+  )
+
+  # Secrets Manager `valueFrom` may reference a JSON key of the secret
+  dd_secret_arns        = distinct([for arn in local.dd_value_from_arns : join(":", slice(split(":", arn), 0, 7)) if split(":", arn)[2] == "secretsmanager"])
+  dd_ssm_parameter_arns = distinct([for arn in local.dd_value_from_arns : arn if split(":", arn)[2] == "ssm"])
+
+  # Customer managed KMS keys used to encrypt the Datadog API key
+  dd_secret_kms_keys = [
+    for source in [var.dd_api_key_secret, var.dd_api_key_ssm_parameter] : {
+      key_arn = source.kms_key_arn
+      service = "${split(":", source.arn)[2]}.${split(":", source.arn)[3]}.amazonaws.com"
+    } if try(source.kms_key_arn, null) != null
+  ]
+}
+
+# ==============================
+# Datadog API Key Secret Policy (Optional)
+# ==============================
+data "aws_iam_policy_document" "dd_secret_access" {
+  count = local.create_dd_secret_perms ? 1 : 0
+
+  dynamic "statement" {
+    for_each = length(local.dd_secret_arns) > 0 ? [local.dd_secret_arns] : []
+
+    content {
+      effect    = "Allow"
+      actions   = ["secretsmanager:GetSecretValue"]
+      resources = statement.value
+    }
+  }
+
+  dynamic "statement" {
+    for_each = length(local.dd_ssm_parameter_arns) > 0 ? [local.dd_ssm_parameter_arns] : []
+
+    content {
+      effect    = "Allow"
+      actions   = ["ssm:GetParameters"]
+      resources = statement.value
+    }
+  }
+
+  dynamic "statement" {
+    for_each = local.dd_secret_kms_keys
+
+    content {
+      effect    = "Allow"
+      actions   = ["kms:Decrypt"]
+      resources = [statement.value.key_arn]
+
+      condition {
+        test     = "StringEquals"
+        variable = "kms:ViaService"
+        values   = [statement.value.service]
+      }
+    }
+  }
+}
+
+resource "aws_iam_policy" "dd_secret_access" {
+  count  = local.create_dd_secret_perms ? 1 : 0
+  name   = "${var.family}-dd-secret-access"
+  policy = data.aws_iam_policy_document.dd_secret_access[0].json
+}
+
+# ==============================
+# Case 1: User provides existing Task Execution Role
+# ==============================
+data "aws_iam_role" "ecs_task_exec_role" {
+  count = local.edit_execution_role ? 1 : 0
+  name  = element(split("/", var.execution_role.arn), 1)
+}
+
+resource "aws_iam_role_policy_attachment" "existing_role_dd_secret" {
+  count      = local.edit_execution_role ? 1 : 0
+  role       = data.aws_iam_role.ecs_task_exec_role[0].name
+  policy_arn = aws_iam_policy.dd_secret_access[0].arn
+}
+
+# ==============================
+# Case 2: Create a Task Execution Role
+# ==============================
+resource "aws_iam_role" "new_ecs_task_execution_role" {
+  count = local.create_execution_role ? 1 : 0
+  name  = "${var.family}-ecs-task-exec-role"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect = "Allow"
+      Principal = {
+        Service = "ecs-tasks.amazonaws.com"
+      }
+      Action = "sts:AssumeRole"
+    }]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "new_ecs_task_execution_role_policy" {
+  count      = local.create_execution_role ? 1 : 0
+  role       = aws_iam_role.new_ecs_task_execution_role[0].name
+  policy_arn = "arn:aws:iam::aws:policy/service-role/AmazonECSTaskExecutionRolePolicy"
+}
+
+resource "aws_iam_role_policy_attachment" "new_role_dd_secret" {
+  count      = local.create_execution_role ? 1 : 0
+  role       = aws_iam_role.new_ecs_task_execution_role[0].name
+  policy_arn = aws_iam_policy.dd_secret_access[0].arn
+}
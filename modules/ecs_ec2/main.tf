@@ -0,0 +1,75 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Datadog Agent Daemon Task Definition
+################################################################################
+
+resource "aws_ecs_task_definition" "this" {
+
+  container_definitions = jsonencode(local.dd_agent_container)
+
+  # Prioritize the user-provided task execution role over the one created by the module
+  execution_role_arn = try(
+    var.execution_role.arn,
+    aws_iam_role.new_ecs_task_execution_role[0].arn,
+    null
+  )
+
+  family                   = var.family
+  network_mode             = var.network_mode
+  requires_compatibilities = ["EC2"]
+  task_role_arn            = try(var.task_role.arn, null)
+
+  dynamic "volume" {
+    for_each = local.host_volumes
+
+    content {
+      name      = volume.value.name
+      host_path = volume.value.host_path
+    }
+  }
+
+  tags = merge(
+    var.tags,
+    local.tags,
+  )
+
+  depends_on = [
+    data.aws_iam_role.ecs_task_exec_role,
+    aws_iam_role.new_ecs_task_execution_role,
+  ]
+
+  lifecycle {
+    create_before_destroy = true
+
+    # Must provide only one of the three Datadog API key options
+    precondition {
+      condition     = length([for source in [var.dd_api_key, var.dd_api_key_secret, var.dd_api_key_ssm_parameter] : source if source != null]) == 1
+      error_message = "You must provide only one of the three Datadog API key options: `dd_api_key`, `dd_api_key_secret` or `dd_api_key_ssm_parameter`."
+    }
+  }
+}
+
+################################################################################
+# Datadog Agent Daemon Service
+################################################################################
+
+# Runs one Datadog Agent on every container instance of the cluster
+resource "aws_ecs_service" "this" {
+  name                = coalesce(var.service_name, var.family)
+  cluster             = var.cluster
+  task_definition     = aws_ecs_task_definition.this.arn
+  launch_type         = "EC2"
+  scheduling_strategy = "DAEMON"
+
+  enable_ecs_managed_tags = true
+  propagate_tags          = "SERVICE"
+
+  tags = merge(
+    var.tags,
+    local.tags,
+  )
+}
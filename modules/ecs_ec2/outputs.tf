@@ -0,0 +1,63 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+output "container_definitions" {
+  description = "The Datadog Agent daemon container definitions provided as a single valid JSON document."
+  value       = aws_ecs_task_definition.this.container_definitions
+}
+
+output "execution_role_arn" {
+  description = "ARN of the task execution role."
+  value       = aws_ecs_task_definition.this.execution_role_arn
+}
+
+output "family" {
+  description = "A unique name for your task definition."
+  value       = aws_ecs_task_definition.this.family
+}
+
+output "network_mode" {
+  description = "Docker networking mode of the Datadog Agent daemon."
+  value       = aws_ecs_task_definition.this.network_mode
+}
+
+output "volume" {
+  description = "Host volumes mounted by the Datadog Agent daemon."
+  value       = aws_ecs_task_definition.this.volume
+}
+
+output "tags" {
+  description = "Key-value map of resource tags."
+  value       = aws_ecs_task_definition.this.tags
+}
+
+# Service outputs
+
+output "service_id" {
+  description = "ARN of the Datadog Agent daemon service."
+  value       = aws_ecs_service.this.id
+}
+
+output "service_name" {
+  description = "Name of the Datadog Agent daemon service."
+  value       = aws_ecs_service.this.name
+}
+
+output "scheduling_strategy" {
+  description = "Scheduling strategy of the Datadog Agent daemon service."
+  value       = aws_ecs_service.this.scheduling_strategy
+}
+
+# Attribute reference outputs
+
+output "arn" {
+  description = "Full ARN of the Task Definition (including both family and revision)."
+  value       = aws_ecs_task_definition.this.arn
+}
+
+output "revision" {
+  description = "Revision of the task in a particular family."
+  value       = aws_ecs_task_definition.this.revision
+}
@@ -0,0 +1,279 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Datadog ECS EC2 Daemon Configuration
+################################################################################
+
+variable "dd_api_key" {
+  description = "Datadog API Key"
+  type        = string
+  default     = null
+}
+
+variable "dd_api_key_secret" {
+  description = "Datadog API Key Secret ARN. Provide `kms_key_arn` when the secret is encrypted with a customer managed KMS key"
+  type = object({
+    arn         = string
+    kms_key_arn = optional(string)
+  })
+  default = null
+  validation {
+    condition     = var.dd_api_key_secret == null || try(var.dd_api_key_secret.arn != null, false)
+    error_message = "If 'dd_api_key_secret' is set, 'arn' must be a non-null string."
+  }
+  validation {
+    condition     = try(var.dd_api_key_secret.kms_key_arn == null, true) || try(can(regex("^arn:[^:]+:kms:[^:]+:[0-9]{12}:key/", var.dd_api_key_secret.kms_key_arn)), false)
+    error_message = "If 'dd_api_key_secret.kms_key_arn' is set, it must be a valid KMS key ARN."
+  }
+}
+
+variable "dd_api_key_ssm_parameter" {
+  description = "Datadog API Key SSM Parameter Store parameter ARN. Provide `kms_key_arn` when the SecureString parameter is encrypted with a customer managed KMS key"
+  type = object({
+    arn         = string
+    kms_key_arn = optional(string)
+  })
+  default = null
+  validation {
+    condition     = var.dd_api_key_ssm_parameter == null || try(can(regex("^arn:[^:]+:ssm:[^:]+:[0-9]{12}:parameter/", var.dd_api_key_ssm_parameter.arn)), false)
+    error_message = "If 'dd_api_key_ssm_parameter' is set, 'arn' must be a valid SSM parameter ARN."
+  }
+  validation {
+    condition     = try(var.dd_api_key_ssm_parameter.kms_key_arn == null, true) || try(can(regex("^arn:[^:]+:kms:[^:]+:[0-9]{12}:key/", var.dd_api_key_ssm_parameter.kms_key_arn)), false)
+    error_message = "If 'dd_api_key_ssm_parameter.kms_key_arn' is set, it must be a valid KMS key ARN."
+  }
+}
+
+variable "dd_registry" {
+  description = "Datadog Agent image registry"
+  type        = string
+  default     = "public.ecr.aws/datadog/agent"
+  nullable    = false
+}
+
+variable "dd_image_version" {
+  description = "Datadog Agent image version"
+  type        = string
+  default     = "latest"
+  nullable    = false
+}
+
+variable "dd_image_digest" {
+  description = "Datadog Agent image digest (for example, `sha256:...`). Takes precedence over `dd_image_version` when set"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.dd_image_digest == null || can(regex("^sha256:[a-f0-9]{64}$", var.dd_image_digest))
+    error_message = "If 'dd_image_digest' is set, it must be a `sha256:` digest."
+  }
+}
+
+variable "dd_cpu" {
+  description = "Datadog Agent container CPU units"
+  type        = number
+  default     = null
+}
+
+variable "dd_memory_limit_mib" {
+  description = "Datadog Agent container memory limit in MiB"
+  type        = number
+  default     = null
+}
+
+variable "dd_health_check" {
+  description = "Datadog Agent health check configuration"
+  type = object({
+    command      = optional(list(string))
+    interval     = optional(number)
+    retries      = optional(number)
+    start_period = optional(number)
+    timeout      = optional(number)
+  })
+  default = {
+    command      = ["CMD-SHELL", "/probe.sh"]
+    interval     = 15
+    retries      = 3
+    start_period = 60
+    timeout      = 5
+  }
+}
+
+variable "dd_site" {
+  description = "Datadog Site"
+  type        = string
+  default     = "datadoghq.com"
+}
+
+variable "dd_environment" {
+  description = "Datadog Agent container environment variables. Highest precedence and overwrites other environment variables defined by the module. For example, `dd_environment = [ { name = 'DD_VAR', value = 'DD_VAL' } ]`"
+  type        = list(map(string))
+  default     = []
+  nullable    = false
+}
+
+variable "dd_secrets" {
+  description = "Datadog Agent container secrets, mapping environment variable names to Secrets Manager secret or SSM parameter ARNs. Overwrites `dd_environment` variables with the same names. For example, `dd_secrets = { DD_APP_KEY = 'arn:aws:secretsmanager:us-east-1:123456789012:secret:dd-app-key' }`"
+  type        = map(string)
+  default     = {}
+  nullable    = false
+  validation {
+    condition     = alltrue([for arn in values(var.dd_secrets) : can(regex("^arn:[^:]+:(secretsmanager:[^:]+:[0-9]{12}:secret:|ssm:[^:]+:[0-9]{12}:parameter/)", arn))])
+    error_message = "All 'dd_secrets' values must be valid Secrets Manager secret or SSM parameter ARNs."
+  }
+  validation {
+    condition     = !contains(keys(var.dd_secrets), "DD_API_KEY")
+    error_message = "The Datadog API key cannot be set in 'dd_secrets'. Please use `dd_api_key_secret` or `dd_api_key_ssm_parameter` instead."
+  }
+}
+
+variable "dd_tags" {
+  description = "Datadog Agent global tags (eg. `key1:value1, key2:value2`)"
+  type        = string
+  default     = null
+}
+
+variable "dd_cluster_name" {
+  description = "Datadog cluster name"
+  type        = string
+  default     = null
+}
+
+variable "dd_checks_cardinality" {
+  description = "Datadog Agent checks cardinality"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.dd_checks_cardinality == null || can(contains(["low", "orchestrator", "high"], var.dd_checks_cardinality))
+    error_message = "The Datadog Agent checks cardinality must be one of 'low', 'orchestrator', 'high', or null."
+  }
+}
+
+variable "dd_dogstatsd" {
+  description = "Configuration for Datadog DogStatsD"
+  type = object({
+    enabled                  = optional(bool, true)
+    origin_detection_enabled = optional(bool, true)
+    dogstatsd_cardinality    = optional(string, "orchestrator")
+    socket_enabled           = optional(bool, true)
+  })
+  default = {
+    enabled                  = true
+    origin_detection_enabled = true
+    dogstatsd_cardinality    = "orchestrator"
+    socket_enabled           = true
+  }
+  validation {
+    condition     = var.dd_dogstatsd != null
+    error_message = "The Datadog Dogstatsd configuration must be defined."
+  }
+  validation {
+    condition     = try(var.dd_dogstatsd.dogstatsd_cardinality == null, false) || can(contains(["low", "orchestrator", "high"], var.dd_dogstatsd.dogstatsd_cardinality))
+    error_message = "The Datadog Dogstatsd cardinality must be one of 'low', 'orchestrator', 'high', or null."
+  }
+}
+
+variable "dd_apm" {
+  description = "Configuration for Datadog APM"
+  type = object({
+    enabled        = optional(bool, true)
+    socket_enabled = optional(bool, true)
+  })
+  default = {
+    enabled        = true
+    socket_enabled = true
+  }
+  validation {
+    condition     = var.dd_apm != null
+    error_message = "The Datadog APM configuration must be defined."
+  }
+}
+
+variable "dd_log_collection" {
+  description = "Configuration for Datadog Log Collection. The Datadog Agent tails the logs of the containers running on the container instance"
+  type = object({
+    enabled               = optional(bool, false)
+    container_collect_all = optional(bool, true)
+  })
+  default = {
+    enabled               = false
+    container_collect_all = true
+  }
+  validation {
+    condition     = var.dd_log_collection != null
+    error_message = "The Datadog Log Collection configuration must be defined."
+  }
+}
+
+################################################################################
+# Task Definition
+################################################################################
+
+variable "family" {
+  description = "A unique name for the Datadog Agent daemon task definition"
+  type        = string
+  default     = "datadog-agent-daemon"
+  nullable    = false
+}
+
+variable "network_mode" {
+  description = "Docker networking mode of the Datadog Agent daemon. Valid values are `bridge` and `host`"
+  type        = string
+  default     = "bridge"
+  nullable    = false
+  validation {
+    condition     = contains(["bridge", "host"], var.network_mode)
+    error_message = "The Datadog Agent daemon 'network_mode' must be 'bridge' or 'host'."
+  }
+}
+
+variable "execution_role" {
+  description = "ARN of the task execution role that the Amazon ECS container agent and the Docker daemon can assume"
+  type = object({
+    arn = string
+  })
+  default = null
+  validation {
+    condition     = var.execution_role == null || try(var.execution_role.arn != null, false)
+    error_message = "If 'execution_role' is set, 'arn' must be a non-null string."
+  }
+}
+
+variable "task_role" {
+  description = "The ARN of the IAM role that allows your Amazon ECS container task to make calls to other AWS services"
+  type = object({
+    arn = string
+  })
+  default = null
+  validation {
+    condition     = var.task_role == null || try(var.task_role.arn != null, false)
+    error_message = "If 'task_role' is set, 'arn' must be a non-null string."
+  }
+}
+
+variable "tags" {
+  description = "A map of additional tags to add to the task definition/set created"
+  type        = map(string)
+  default     = null
+}
+
+################################################################################
+# ECS Service
+################################################################################
+
+variable "cluster" {
+  description = "Name or ARN of the ECS cluster running the Datadog Agent daemon service"
+  type        = string
+  validation {
+    condition     = var.cluster != null && var.cluster != ""
+    error_message = "The 'cluster' must be a non-empty cluster name or ARN."
+  }
+}
+
+variable "service_name" {
+  description = "Name of the Datadog Agent daemon service. Defaults to the task definition family"
+  type        = string
+  default     = null
+}
@@ -0,0 +1,75 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# Unified Service Tagging and Datadog sockets
+module "dd_common" {
+  source = "../ecs_common"
+
+  dd_service = var.dd_service
+  dd_env     = var.dd_env
+  dd_version = var.dd_version
+
+  # The sockets are created by the Datadog Agent daemon in a host directory
+  is_apm_socket_enabled = local.is_apm_socket_mount
+  is_dsd_socket_enabled = local.is_dsd_socket_mount
+}
+
+locals {
+
+  is_apm_socket_mount = var.dd_apm.enabled && var.dd_apm.socket_enabled
+  is_dsd_socket_mount = var.dd_dogstatsd.enabled && var.dd_dogstatsd.socket_enabled
+  is_apm_dsd_volume   = local.is_apm_socket_mount || local.is_dsd_socket_mount
+
+  # Without sockets, traces and metrics are sent to the Datadog Agent daemon ports on the host.
+  # The host is reachable through the docker bridge gateway in `bridge` mode, and through the
+  # loopback interface in `host` mode. Tasks in `awsvpc` mode cannot reach the host ports.
+  agent_host    = var.network_mode == "host" ? "127.0.0.1" : var.docker_bridge_gateway
+  is_agent_host = (var.dd_apm.enabled && !local.is_apm_socket_mount) || (var.dd_dogstatsd.enabled && !local.is_dsd_socket_mount)
+
+  agent_host_var = local.is_agent_host ? [
+    {
+      name  = "DD_AGENT_HOST"
+      value = local.agent_host
+    }
+  ] : []
+
+  # Container definitions may be provided as a JSON string or as a list of objects
+  container_definitions = try(jsondecode(var.container_definitions), var.container_definitions)
+
+  modified_container_definitions = [
+    for container in local.container_definitions : merge(
+      container,
+      {
+        # Append new environment variables to any existing ones.
+        environment = concat(
+          lookup(container, "environment", []),
+          module.dd_common.apm_dsd_socket_env_vars,
+          local.agent_host_var,
+          module.dd_common.ust_env_vars,
+        ),
+        # Append new volume mounts to any existing mountPoints.
+        mountPoints = concat(
+          lookup(container, "mountPoints", []),
+          module.dd_common.apm_dsd_mount_points,
+        )
+      },
+      # Only add the UST docker labels if the values are provided
+      length(module.dd_common.ust_env_vars) > 0 ? {
+        dockerLabels = merge(
+          lookup(container, "dockerLabels", {}),
+          module.dd_common.ust_docker_labels,
+        )
+      } : {},
+    )
+  ]
+
+  # Host volume of the Datadog Agent daemon sockets
+  volumes = local.is_apm_dsd_volume ? [
+    {
+      name      = "dd-sockets"
+      host_path = "/var/run/datadog"
+    }
+  ] : []
+}
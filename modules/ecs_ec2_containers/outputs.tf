@@ -0,0 +1,19 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+output "container_definitions" {
+  description = "The instrumented application container definitions provided as a single valid JSON document."
+  value       = jsonencode(local.modified_container_definitions)
+
+  precondition {
+    condition     = var.network_mode != "awsvpc" || !local.is_agent_host
+    error_message = "Tasks in `awsvpc` network mode cannot reach the Datadog Agent daemon ports. Please enable `socket_enabled` for `dd_apm` and `dd_dogstatsd`."
+  }
+}
+
+output "volumes" {
+  description = "Host volumes to add to the application task definition, consumable by the `volume` block of `aws_ecs_task_definition`."
+  value       = local.volumes
+}
@@ -0,0 +1,98 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Datadog ECS EC2 Application Configuration
+################################################################################
+
+variable "dd_dogstatsd" {
+  description = "Configuration for Datadog DogStatsD. Must match the configuration of the Datadog Agent daemon"
+  type = object({
+    enabled        = optional(bool, true)
+    socket_enabled = optional(bool, true)
+  })
+  default = {
+    enabled        = true
+    socket_enabled = true
+  }
+  validation {
+    condition     = var.dd_dogstatsd != null
+    error_message = "The Datadog Dogstatsd configuration must be defined."
+  }
+}
+
+variable "dd_apm" {
+  description = "Configuration for Datadog APM. Must match the configuration of the Datadog Agent daemon"
+  type = object({
+    enabled        = optional(bool, true)
+    socket_enabled = optional(bool, true)
+  })
+  default = {
+    enabled        = true
+    socket_enabled = true
+  }
+  validation {
+    condition     = var.dd_apm != null
+    error_message = "The Datadog APM configuration must be defined."
+  }
+}
+
+variable "dd_service" {
+  description = "The task service name. Used for tagging (UST)"
+  type        = string
+  default     = null
+}
+
+variable "dd_env" {
+  description = "The task environment name. Used for tagging (UST)"
+  type        = string
+  default     = null
+}
+
+variable "dd_version" {
+  description = "The task version name. Used for tagging (UST)"
+  type        = string
+  default     = null
+}
+
+################################################################################
+# Container Definitions
+################################################################################
+
+# Note: typed as `any` since it accepts either a JSON string or a list of objects
+variable "container_definitions" {
+  description = "A list of valid [container definitions](http://docs.aws.amazon.com/AmazonECS/latest/APIReference/API_ContainerDefinition.html), provided either as a JSON string or as a list of objects. Please note that you should only provide values that are part of the container definition document"
+  type        = any
+  validation {
+    condition     = can([for container in try(jsondecode(var.container_definitions), var.container_definitions) : container]) && !can(keys(try(jsondecode(var.container_definitions), var.container_definitions)))
+    error_message = "The `container_definitions` must be a JSON string or a list of container definitions."
+  }
+  validation {
+    condition     = try(alltrue([for container in try(jsondecode(var.container_definitions), var.container_definitions) : try(container.name != null && container.name != "" && container.image != null && container.image != "", false)]), false)
+    error_message = "Each container definition must define a non-empty `name` and `image`."
+  }
+}
+
+variable "network_mode" {
+  description = "Docker networking mode of the application task. Valid values are `bridge`, `host` and `awsvpc`"
+  type        = string
+  default     = "bridge"
+  nullable    = false
+  validation {
+    condition     = contains(["bridge", "host", "awsvpc"], var.network_mode)
+    error_message = "The application 'network_mode' must be 'bridge', 'host' or 'awsvpc'."
+  }
+}
+
+variable "docker_bridge_gateway" {
+  description = "IP address of the docker bridge network gateway, through which tasks in `bridge` network mode reach the Datadog Agent daemon ports. Defaults to the `docker0` gateway of the ECS-optimized AMIs. Change it when the docker daemon of the container instances is configured with a custom bridge IP (`bip`)"
+  type        = string
+  default     = "172.17.0.1"
+  nullable    = false
+  validation {
+    condition     = can(regex("^([0-9]{1,3}\\.){3}[0-9]{1,3}$", var.docker_bridge_gateway))
+    error_message = "The 'docker_bridge_gateway' must be a valid IPv4 address."
+  }
+}
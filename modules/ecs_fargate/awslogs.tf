@@ -0,0 +1,78 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# ==============================
+# Windows Log Collection (Optional)
+# ==============================
+
+# Will create the `awslogs` log group of Windows tasks unless managed by the
+# user, and forward it to Datadog when the Datadog Forwarder is provided.
+# Both are managed in the provider region, which must be the log group region.
+
+data "aws_region" "current" {}
+
+locals {
+  is_dd_awslogs         = module.dd_containers.is_awslogs_enabled
+  create_dd_log_group   = local.is_dd_awslogs && try(var.dd_log_collection.awslogs_config.create_log_group, false)
+  is_dd_forwarder       = local.is_dd_awslogs && try(var.dd_log_collection.awslogs_config.forwarder_arn != null, false)
+  dd_awslogs_region     = try(module.dd_containers.awslogs_log_configuration.options["awslogs-region"], null)
+  dd_awslogs_group_name = try(var.dd_log_collection.awslogs_config.log_group_name, null)
+  dd_awslogs_group_arn  = try(aws_cloudwatch_log_group.dd_awslogs[0].arn, data.aws_cloudwatch_log_group.dd_awslogs[0].arn, null)
+
+  is_dd_awslogs_provider_region = local.dd_awslogs_region == data.aws_region.current.name
+}
+
+resource "aws_cloudwatch_log_group" "dd_awslogs" {
+  count             = local.create_dd_log_group ? 1 : 0
+  name              = local.dd_awslogs_group_name
+  retention_in_days = var.dd_log_collection.awslogs_config.retention_in_days
+
+  tags = merge(
+    var.tags,
+    local.tags,
+  )
+
+  lifecycle {
+    precondition {
+      condition     = local.is_dd_awslogs_provider_region
+      error_message = "The `awslogs` log group is created in the provider region ${data.aws_region.current.name}. Please set `dd_log_collection.awslogs_config.region` to the provider region, or set `create_log_group` to `false` to use an existing log group."
+    }
+  }
+}
+
+data "aws_cloudwatch_log_group" "dd_awslogs" {
+  count = local.is_dd_forwarder && !local.create_dd_log_group ? 1 : 0
+  name  = local.dd_awslogs_group_name
+}
+
+# The Datadog Forwarder must allow CloudWatch Logs to invoke it
+resource "aws_lambda_permission" "dd_forwarder" {
+  count         = local.is_dd_forwarder ? 1 : 0
+  statement_id  = "${var.family}-datadog-forwarder"
+  action        = "lambda:InvokeFunction"
+  function_name = var.dd_log_collection.awslogs_config.forwarder_arn
+  principal     = "logs.${local.dd_awslogs_region}.amazonaws.com"
+  source_arn    = "${local.dd_awslogs_group_arn}:*"
+
+  lifecycle {
+    precondition {
+      condition     = local.is_dd_awslogs_provider_region
+      error_message = "The Datadog Forwarder subscription is created in the provider region ${data.aws_region.current.name}. Please set `dd_log_collection.awslogs_config.region` to the provider region, or unset `forwarder_arn` and forward the log group separately."
+    }
+  }
+}
+
+resource "aws_cloudwatch_log_subscription_filter" "dd_forwarder" {
+  count           = local.is_dd_forwarder ? 1 : 0
+  name            = "${var.family}-datadog-forwarder"
+  log_group_name  = local.dd_awslogs_group_name
+  filter_pattern  = var.dd_log_collection.awslogs_config.filter_pattern
+  destination_arn = var.dd_log_collection.awslogs_config.forwarder_arn
+
+  depends_on = [
+    aws_cloudwatch_log_group.dd_awslogs,
+    aws_lambda_permission.dd_forwarder,
+  ]
+}
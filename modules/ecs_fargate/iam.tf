@@ -4,155 +4,68 @@
 # Copyright 2025-present Datadog, Inc.
 
 # ==============================
-# Task Execution Role
+# Task Execution Role and Task Role
 # ==============================
 
-# Will create or edit the *task execution role*
-# only if the user provides a Datadog API key secret ARN
-# in order to provide permissions to access the secret
+# Will create or edit the *task execution role* only if the
+# Datadog secrets require permissions, and the *task role*
+# always in order to add permissions for the ecs_fargate check
 
-locals {
-  create_dd_secret_perms = var.dd_api_key_secret != null
-  edit_execution_role    = var.execution_role != null && local.create_dd_secret_perms
-  create_execution_role  = var.execution_role == null && local.create_dd_secret_perms
-}
+module "dd_task_roles" {
+  source = "../ecs_task_roles"
 
-# ==============================
-# Datadog API Key Secret Policy (Optional)
-# ==============================
-data "aws_iam_policy_document" "dd_secret_access" {
-  count = local.create_dd_secret_perms ? 1 : 0
+  name = var.family
 
-  statement {
-    effect    = "Allow"
-    actions   = ["secretsmanager:GetSecretValue"]
-    resources = [var.dd_api_key_secret.arn]
-  }
-}
+  execution_role                    = var.execution_role
+  is_execution_role_policy_required = module.dd_containers.is_execution_role_policy_required
+  execution_role_policy_statements  = module.dd_containers.execution_role_policy_statements
 
-resource "aws_iam_policy" "dd_secret_access" {
-  count  = local.create_dd_secret_perms ? 1 : 0
-  name   = "${var.family}-dd-secret-access"
-  policy = data.aws_iam_policy_document.dd_secret_access[0].json
+  task_role                   = var.task_role
+  task_role_policy_statements = module.dd_containers.task_role_policy_statements
 }
 
-# ==============================
-# Case 1: User provides existing Task Execution Role
-# ==============================
-data "aws_iam_role" "ecs_task_exec_role" {
-  count = local.edit_execution_role ? 1 : 0
-  name  = element(split("/", var.execution_role.arn), 1)
+# The roles and policies were previously managed by this module
+moved {
+  from = aws_iam_policy.dd_secret_access
+  to   = module.dd_task_roles.aws_iam_policy.dd_secret_access
 }
 
-resource "aws_iam_role_policy_attachment" "existing_role_dd_secret" {
-  count      = local.edit_execution_role ? 1 : 0
-  role       = data.aws_iam_role.ecs_task_exec_role[0].name
-  policy_arn = aws_iam_policy.dd_secret_access[0].arn
+moved {
+  from = aws_iam_role_policy_attachment.existing_role_dd_secret
+  to   = module.dd_task_roles.aws_iam_role_policy_attachment.existing_role_dd_secret
 }
 
-# ==============================
-# Case 2: Create a Task Execution Role
-# ==============================
-resource "aws_iam_role" "new_ecs_task_execution_role" {
-  count = local.create_execution_role ? 1 : 0
-  name  = "${var.family}-ecs-task-exec-role"
-
-  assume_role_policy = jsonencode({
-    Version = "2012-10-17"
-    Statement = [{
-      Effect = "Allow"
-      Principal = {
-        Service = "ecs-tasks.amazonaws.com"
-      }
-      Action = "sts:AssumeRole"
-    }]
-  })
+moved {
+  from = aws_iam_role.new_ecs_task_execution_role
+  to   = module.dd_task_roles.aws_iam_role.new_ecs_task_execution_role
 }
 
-resource "aws_iam_role_policy_attachment" "new_ecs_task_execution_role_policy" {
-  count      = local.create_execution_role ? 1 : 0
-  role       = aws_iam_role.new_ecs_task_execution_role[0].name
-  policy_arn = "arn:aws:iam::aws:policy/service-role/AmazonECSTaskExecutionRolePolicy"
+moved {
+  from = aws_iam_role_policy_attachment.new_ecs_task_execution_role_policy
+  to   = module.dd_task_roles.aws_iam_role_policy_attachment.new_ecs_task_execution_role_policy
 }
 
-resource "aws_iam_role_policy_attachment" "new_role_dd_secret" {
-  count      = local.create_execution_role ? 1 : 0
-  role       = aws_iam_role.new_ecs_task_execution_role[0].name
-  policy_arn = aws_iam_policy.dd_secret_access[0].arn
+moved {
+  from = aws_iam_role_policy_attachment.new_role_dd_secret
+  to   = module.dd_task_roles.aws_iam_role_policy_attachment.new_role_dd_secret
 }
 
-# ==============================
-# Task Role
-# ==============================
-
-# Will create or edit the *task role* always
-# in order to add permissions for the ecs_fargate check
-
-locals {
-  edit_task_role   = var.task_role != null
-  create_task_role = var.task_role == null
+moved {
+  from = aws_iam_policy.dd_ecs_task_permissions
+  to   = module.dd_task_roles.aws_iam_policy.dd_ecs_task_permissions
 }
 
-# ==============================
-# ECS Task Permissions Policy
-# ==============================
-data "aws_iam_policy_document" "dd_ecs_task_permissions" {
-  statement {
-    effect = "Allow"
-    actions = [
-      "ecs:ListClusters",
-      "ecs:ListContainerInstances",
-      "ecs:DescribeContainerInstances"
-    ]
-    resources = ["*"]
-  }
+moved {
+  from = aws_iam_role_policy_attachment.existing_role_ecs_task_permissions
+  to   = module.dd_task_roles.aws_iam_role_policy_attachment.existing_role_ecs_task_permissions
 }
 
-resource "aws_iam_policy" "dd_ecs_task_permissions" {
-  name   = "${var.family}-dd-ecs-task-policy"
-  policy = data.aws_iam_policy_document.dd_ecs_task_permissions.json
-}
-
-# ==============================
-# Case 1: User provides existing Task Role
-# ==============================
-
-data "aws_iam_role" "ecs_task_role" {
-  count = local.edit_task_role ? 1 : 0
-  name  = element(split("/", var.task_role.arn), 1)
-}
-
-# Always attach `dd_ecs_task_permissions`
-resource "aws_iam_role_policy_attachment" "existing_role_ecs_task_permissions" {
-  count      = local.edit_task_role ? 1 : 0
-  role       = data.aws_iam_role.ecs_task_role[0].name
-  policy_arn = aws_iam_policy.dd_ecs_task_permissions.arn
-}
-
-
-# ==============================
-# Case 2: Create a Task Role
-# ==============================
-
-resource "aws_iam_role" "new_ecs_task_role" {
-  count = local.create_task_role ? 1 : 0
-  name  = "${var.family}-ecs-task-role"
-
-  assume_role_policy = jsonencode({
-    Version = "2012-10-17"
-    Statement = [{
-      Effect = "Allow"
-      Principal = {
-        Service = "ecs-tasks.amazonaws.com"
-      }
-      Action = "sts:AssumeRole"
-    }]
-  })
+moved {
+  from = aws_iam_role.new_ecs_task_role
+  to   = module.dd_task_roles.aws_iam_role.new_ecs_task_role
 }
 
-# Always attach `dd_ecs_task_permissions`
-resource "aws_iam_role_policy_attachment" "new_role_ecs_task_permissions" {
-  count      = local.create_task_role ? 1 : 0
-  role       = aws_iam_role.new_ecs_task_role[0].name
-  policy_arn = aws_iam_policy.dd_ecs_task_permissions.arn
+moved {
+  from = aws_iam_role_policy_attachment.new_role_ecs_task_permissions
+  to   = module.dd_task_roles.aws_iam_role_policy_attachment.new_role_ecs_task_permissions
 }
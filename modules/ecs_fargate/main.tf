@@ -9,14 +9,7 @@
 
 resource "aws_ecs_task_definition" "this" {
 
-  container_definitions = jsonencode(
-    concat(
-      local.dd_agent_container,
-      local.dd_log_container,
-      local.dd_cws_container,
-      [for k, v in local.modified_container_definitions : v],
-    )
-  )
+  container_definitions = module.dd_containers.container_definitions
 
   cpu = var.cpu
 
@@ -31,11 +24,7 @@ resource "aws_ecs_task_definition" "this" {
   enable_fault_injection = var.enable_fault_injection
 
   # Prioritize the user-provided task execution role over the one created by the module
-  execution_role_arn = try(
-    var.execution_role.arn,
-    aws_iam_role.new_ecs_task_execution_role[0].arn,
-    null
-  )
+  execution_role_arn = module.dd_task_roles.execution_role_arn
 
   family = var.family
 
@@ -87,14 +76,10 @@ resource "aws_ecs_task_definition" "this" {
 
   skip_destroy = var.skip_destroy
   # Prioritize the user-provided task role over the one created by the module
-  task_role_arn = try(
-    var.task_role.arn,
-    aws_iam_role.new_ecs_task_role[0].arn,
-    null
-  )
+  task_role_arn = module.dd_task_roles.task_role_arn
 
   dynamic "volume" {
-    for_each = local.modified_volumes
+    for_each = module.dd_containers.volumes
 
     content {
       dynamic "docker_volume_configuration" {
@@ -161,28 +146,16 @@ resource "aws_ecs_task_definition" "this" {
   track_latest = var.track_latest
 
   depends_on = [
-    data.aws_iam_role.ecs_task_role,
-    data.aws_iam_role.ecs_task_exec_role,
-    aws_iam_role.new_ecs_task_role,
-    aws_iam_role.new_ecs_task_execution_role,
+    module.dd_task_roles,
   ]
 
   lifecycle {
     create_before_destroy = true
 
-    # Attach any complex Datadog configuration rules (multiple variables)
-    precondition {
-      condition     = var.dd_cws.enabled == false || (var.dd_cws.enabled == true && var.dd_is_datadog_dependency_enabled == true)
-      error_message = "The Datadog Agent container dependency must be enabled for CWS to be stable. Please set `dd_is_datadog_dependency_enabled` to `true`."
-    }
-    precondition {
-      condition     = var.dd_log_collection.enabled == false || (var.dd_log_collection.enabled == true && local.is_linux == true)
-      error_message = "Log collection is not supported on Windows. Please set `dd_log_collection.enabled` to `false`."
-    }
-    # Must provide only one of the two Datadog API key options
+    # The process agent reads the processes of the other containers through the task PID namespace
     precondition {
-      condition     = (var.dd_api_key == null && var.dd_api_key_secret != null) || (var.dd_api_key != null && var.dd_api_key_secret == null)
-      error_message = "You must provide only one of the two Datadog API key options: `dd_api_key` or `dd_api_key_secret`."
+      condition     = var.dd_collection.process_collection.enabled == false || var.pid_mode == "task"
+      error_message = "Datadog process collection requires `pid_mode` to be set to `task`."
     }
   }
 }
@@ -103,6 +103,40 @@ output "volume" {
   value       = aws_ecs_task_definition.this.volume
 }
 
+# Datadog outputs
+
+output "dd_log_group_name" {
+  description = "Name of the CloudWatch log group of the `awslogs` log driver used for Windows log collection."
+  value       = local.is_dd_awslogs ? local.dd_awslogs_group_name : null
+}
+
+output "dd_secret_access_policy" {
+  description = "JSON policy document granting the task execution role access to the Datadog secrets, if any."
+  value       = module.dd_task_roles.dd_secret_access_policy
+}
+
+output "dd_sidecar_resources" {
+  description = "CPU units and memory (MiB) of the rendered Datadog sidecars, computed in the `auto` mode of `dd_resource_sizing`. Null for sidecars that are not rendered."
+  value       = module.dd_containers.dd_sidecar_resources
+}
+
+output "dd_task_permissions_policy" {
+  description = "JSON policy document granting the task role the permissions required by the Datadog sidecars."
+  value       = module.dd_task_roles.dd_task_permissions_policy
+}
+
+# Service outputs
+
+output "service_id" {
+  description = "ARN of the ECS service, if created."
+  value       = try(aws_ecs_service.this[0].id, null)
+}
+
+output "service_name" {
+  description = "Name of the ECS service, if created."
+  value       = try(aws_ecs_service.this[0].name, null)
+}
+
 # Attribute reference outputs
 
 output "arn" {
@@ -0,0 +1,67 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# ECS Service (Optional)
+################################################################################
+
+locals {
+  # Unified Service Tagging (UST) tags, propagated by the service to its tasks
+  ust_tags = {
+    for key, value in {
+      env     = var.dd_env
+      service = var.dd_service
+      version = var.dd_version
+    } : key => value if value != null
+  }
+}
+
+resource "aws_ecs_service" "this" {
+  count = var.service != null ? 1 : 0
+
+  name            = coalesce(var.service.name, var.family)
+  cluster         = var.service.cluster
+  task_definition = aws_ecs_task_definition.this.arn
+  desired_count   = var.service.desired_count
+
+  # The launch type and the capacity provider strategy are mutually exclusive
+  launch_type      = length(var.service.capacity_provider_strategy) == 0 ? "FARGATE" : null
+  platform_version = var.service.platform_version
+
+  dynamic "capacity_provider_strategy" {
+    for_each = var.service.capacity_provider_strategy
+
+    content {
+      capacity_provider = capacity_provider_strategy.value.capacity_provider
+      weight            = capacity_provider_strategy.value.weight
+      base              = capacity_provider_strategy.value.base
+    }
+  }
+
+  network_configuration {
+    subnets          = var.service.subnets
+    security_groups  = var.service.security_groups
+    assign_public_ip = var.service.assign_public_ip
+  }
+
+  deployment_circuit_breaker {
+    enable   = var.service.deployment_circuit_breaker.enable
+    rollback = var.service.deployment_circuit_breaker.rollback
+  }
+
+  deployment_minimum_healthy_percent = var.service.deployment_minimum_healthy_percent
+  deployment_maximum_percent         = var.service.deployment_maximum_percent
+  wait_for_steady_state              = var.service.wait_for_steady_state
+
+  enable_ecs_managed_tags = true
+  propagate_tags          = "SERVICE"
+
+  tags = merge(
+    var.tags,
+    var.service.tags,
+    local.tags,
+    local.ust_tags,
+  )
+}
@@ -14,15 +14,37 @@ variable "dd_api_key" {
 }
 
 variable "dd_api_key_secret" {
-  description = "Datadog API Key Secret ARN"
+  description = "Datadog API Key Secret ARN. Provide `kms_key_arn` when the secret is encrypted with a customer managed KMS key"
   type = object({
-    arn = string
+    arn         = string
+    kms_key_arn = optional(string)
   })
   default = null
   validation {
     condition     = var.dd_api_key_secret == null || try(var.dd_api_key_secret.arn != null, false)
     error_message = "If 'dd_api_key_secret' is set, 'arn' must be a non-null string."
   }
+  validation {
+    condition     = try(var.dd_api_key_secret.kms_key_arn == null, true) || try(can(regex("^arn:[^:]+:kms:[^:]+:[0-9]{12}:key/", var.dd_api_key_secret.kms_key_arn)), false)
+    error_message = "If 'dd_api_key_secret.kms_key_arn' is set, it must be a valid KMS key ARN."
+  }
+}
+
+variable "dd_api_key_ssm_parameter" {
+  description = "Datadog API Key SSM Parameter Store parameter ARN. Provide `kms_key_arn` when the SecureString parameter is encrypted with a customer managed KMS key"
+  type = object({
+    arn         = string
+    kms_key_arn = optional(string)
+  })
+  default = null
+  validation {
+    condition     = var.dd_api_key_ssm_parameter == null || try(can(regex("^arn:[^:]+:ssm:[^:]+:[0-9]{12}:parameter/", var.dd_api_key_ssm_parameter.arn)), false)
+    error_message = "If 'dd_api_key_ssm_parameter' is set, 'arn' must be a valid SSM parameter ARN."
+  }
+  validation {
+    condition     = try(var.dd_api_key_ssm_parameter.kms_key_arn == null, true) || try(can(regex("^arn:[^:]+:kms:[^:]+:[0-9]{12}:key/", var.dd_api_key_ssm_parameter.kms_key_arn)), false)
+    error_message = "If 'dd_api_key_ssm_parameter.kms_key_arn' is set, it must be a valid KMS key ARN."
+  }
 }
 
 variable "dd_registry" {
@@ -39,6 +61,35 @@ variable "dd_image_version" {
   nullable    = false
 }
 
+variable "dd_image_digest" {
+  description = "Datadog Agent image digest (for example, `sha256:...`). Takes precedence over `dd_image_version` when set"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.dd_image_digest == null || can(regex("^sha256:[a-f0-9]{64}$", var.dd_image_digest))
+    error_message = "If 'dd_image_digest' is set, it must be a `sha256:` digest."
+  }
+}
+
+variable "dd_require_image_digests" {
+  description = "Whether to fail the plan when any Datadog sidecar image is referenced by a mutable tag instead of a digest"
+  type        = bool
+  default     = false
+  nullable    = false
+}
+
+variable "dd_repository_credentials" {
+  description = "Datadog Agent private registry credentials. `credentials_parameter` is the ARN of the Secrets Manager secret containing the registry username and password"
+  type = object({
+    credentials_parameter = string
+  })
+  default = null
+  validation {
+    condition     = var.dd_repository_credentials == null || try(can(regex("^arn:[^:]+:secretsmanager:[^:]+:[0-9]{12}:secret:", var.dd_repository_credentials.credentials_parameter)), false)
+    error_message = "If 'dd_repository_credentials' is set, 'credentials_parameter' must be a valid Secrets Manager secret ARN."
+  }
+}
+
 variable "dd_cpu" {
   description = "Datadog Agent container CPU units"
   type        = number
@@ -51,6 +102,17 @@ variable "dd_memory_limit_mib" {
   default     = null
 }
 
+variable "dd_memory_limit_type" {
+  description = "Whether `dd_memory_limit_mib` is a hard limit (`memory`) or a soft limit (`memoryReservation`) of the Datadog Agent container"
+  type        = string
+  default     = "hard"
+  nullable    = false
+  validation {
+    condition     = contains(["hard", "soft"], var.dd_memory_limit_type)
+    error_message = "The Datadog Agent memory limit type must be one of 'hard' or 'soft'."
+  }
+}
+
 variable "dd_essential" {
   description = "Whether the Datadog Agent container is essential"
   type        = bool
@@ -65,6 +127,34 @@ variable "dd_is_datadog_dependency_enabled" {
   nullable    = false
 }
 
+variable "dd_resource_sizing" {
+  description = "Sizing of the Datadog sidecars. In `auto` mode, the Datadog Agent, log router and CWS instrumentation CPU and memory reservations are computed from the enabled features and the task size, unless explicitly set, and the application containers must keep at least `min_app_share` of the task CPU and memory"
+  type = object({
+    mode          = optional(string, "manual")
+    min_app_share = optional(number, 0.5)
+  })
+  default = {
+    mode          = "manual"
+    min_app_share = 0.5
+  }
+  nullable = false
+  validation {
+    condition     = try(contains(["manual", "auto"], var.dd_resource_sizing.mode), false)
+    error_message = "The Datadog resource sizing mode must be one of 'manual' or 'auto'."
+  }
+  validation {
+    condition     = try(var.dd_resource_sizing.min_app_share >= 0 && var.dd_resource_sizing.min_app_share < 1, false)
+    error_message = "The Datadog resource sizing 'min_app_share' must be between 0 (inclusive) and 1 (exclusive)."
+  }
+}
+
+variable "dd_readonly_root_filesystem" {
+  description = "Whether the Datadog sidecars run with a read-only root filesystem. Writable task storage volumes are added for the Datadog Agent and log router scratch directories, the Datadog Agent configuration directory is copied to a writable volume by an init container, and the log router no longer runs as root"
+  type        = bool
+  default     = false
+  nullable    = false
+}
+
 variable "dd_health_check" {
   description = "Datadog Agent health check configuration"
   type = object({
@@ -96,6 +186,21 @@ variable "dd_environment" {
   nullable    = false
 }
 
+variable "dd_secrets" {
+  description = "Datadog Agent container secrets, mapping environment variable names to Secrets Manager secret or SSM parameter ARNs. Overwrites `dd_environment` variables with the same names. For example, `dd_secrets = { DD_APP_KEY = 'arn:aws:secretsmanager:us-east-1:123456789012:secret:dd-app-key' }`"
+  type        = map(string)
+  default     = {}
+  nullable    = false
+  validation {
+    condition     = alltrue([for arn in values(var.dd_secrets) : can(regex("^arn:[^:]+:(secretsmanager:[^:]+:[0-9]{12}:secret:|ssm:[^:]+:[0-9]{12}:parameter/)", arn))])
+    error_message = "All 'dd_secrets' values must be valid Secrets Manager secret or SSM parameter ARNs."
+  }
+  validation {
+    condition     = !contains(keys(var.dd_secrets), "DD_API_KEY")
+    error_message = "The Datadog API key cannot be set in 'dd_secrets'. Please use `dd_api_key_secret` or `dd_api_key_ssm_parameter` instead."
+  }
+}
+
 variable "dd_tags" {
   description = "Datadog Agent global tags (eg. `key1:value1, key2:value2`)"
   type        = string
@@ -143,6 +248,7 @@ variable "dd_dogstatsd" {
     origin_detection_enabled = optional(bool, true)
     dogstatsd_cardinality    = optional(string, "orchestrator")
     socket_enabled           = optional(bool, true)
+    windows_pipe_name        = optional(string)
   })
   default = {
     enabled                  = true
@@ -158,6 +264,10 @@ variable "dd_dogstatsd" {
     condition     = try(var.dd_dogstatsd.dogstatsd_cardinality == null, false) || can(contains(["low", "orchestrator", "high"], var.dd_dogstatsd.dogstatsd_cardinality))
     error_message = "The Datadog Dogstatsd cardinality must be one of 'low', 'orchestrator', 'high', or null."
   }
+  validation {
+    condition     = try(var.dd_dogstatsd.windows_pipe_name == null, false) || try(can(regex("^[A-Za-z0-9_.-]+$", var.dd_dogstatsd.windows_pipe_name)), false)
+    error_message = "The Datadog Dogstatsd Windows pipe name must only contain letters, digits, '_', '.' or '-', without the `\\\\.\\pipe\\` prefix."
+  }
 }
 
 variable "dd_apm" {
@@ -165,8 +275,23 @@ variable "dd_apm" {
   type = object({
     enabled                       = optional(bool, true)
     socket_enabled                = optional(bool, true)
+    windows_pipe_name             = optional(string)
     profiling                     = optional(bool, false)
     trace_inferred_proxy_services = optional(bool, false)
+    trace_sample_rate             = optional(number)
+    trace_rate_limit              = optional(number)
+    trace_sampling_rules = optional(list(object({
+      sample_rate    = number
+      service        = optional(string)
+      name           = optional(string)
+      resource       = optional(string)
+      tags           = optional(map(string))
+      max_per_second = optional(number)
+    })))
+    dbm_propagation_mode    = optional(string)
+    data_streams_enabled    = optional(bool)
+    logs_injection          = optional(bool)
+    runtime_metrics_enabled = optional(bool)
   })
   default = {
     enabled                       = true
@@ -178,19 +303,99 @@ variable "dd_apm" {
     condition     = var.dd_apm != null
     error_message = "The Datadog APM configuration must be defined."
   }
+  validation {
+    condition     = try(var.dd_apm.trace_sample_rate == null, false) || try(var.dd_apm.trace_sample_rate >= 0 && var.dd_apm.trace_sample_rate <= 1, false)
+    error_message = "The Datadog APM trace sample rate must be between 0 and 1."
+  }
+  validation {
+    condition     = try(var.dd_apm.trace_rate_limit == null, false) || try(var.dd_apm.trace_rate_limit >= 0, false)
+    error_message = "The Datadog APM trace rate limit must be a non-negative number."
+  }
+  validation {
+    condition     = try(var.dd_apm.trace_sampling_rules == null, false) || try(alltrue([for rule in var.dd_apm.trace_sampling_rules : rule.sample_rate >= 0 && rule.sample_rate <= 1]), false)
+    error_message = "The Datadog APM trace sampling rules sample rate must be between 0 and 1."
+  }
+  validation {
+    condition     = try(var.dd_apm.dbm_propagation_mode == null, false) || try(contains(["disabled", "service", "full"], var.dd_apm.dbm_propagation_mode), false)
+    error_message = "The Datadog APM DBM propagation mode must be one of 'disabled', 'service', 'full', or null."
+  }
+  validation {
+    condition     = try(var.dd_apm.windows_pipe_name == null, false) || try(can(regex("^[A-Za-z0-9_.-]+$", var.dd_apm.windows_pipe_name)), false)
+    error_message = "The Datadog APM Windows pipe name must only contain letters, digits, '_', '.' or '-', without the `\\\\.\\pipe\\` prefix."
+  }
+}
+
+variable "dd_appsec" {
+  description = "Configuration for Datadog Application Security Management (ASM) on application containers. Unset values are left to the tracer defaults so that ASM can still be activated remotely"
+  type = object({
+    threat_detection_enabled = optional(bool)
+    iast_enabled             = optional(bool)
+    sca_enabled              = optional(bool)
+    rules_file               = optional(string)
+    blocking_enabled         = optional(bool)
+    blocked_template_html    = optional(string)
+    blocked_template_json    = optional(string)
+  })
+  default = {}
+  validation {
+    condition     = var.dd_appsec != null
+    error_message = "The Datadog Application Security Management (ASM) configuration must be defined."
+  }
+  validation {
+    condition     = try(var.dd_appsec.blocking_enabled != true, true) || try(var.dd_appsec.threat_detection_enabled != false, true)
+    error_message = "Datadog ASM blocking requires threat detection. Please do not set `threat_detection_enabled` to `false` when `blocking_enabled` is `true`."
+  }
+}
+
+variable "dd_otlp" {
+  description = "Configuration for Datadog OpenTelemetry (OTLP) ingestion through the Datadog Agent. `exporter_protocol` must be one of `grpc` or `http/protobuf`"
+  type = object({
+    enabled                    = optional(bool, false)
+    grpc_enabled               = optional(bool, true)
+    http_enabled               = optional(bool, true)
+    logs_enabled               = optional(bool, false)
+    exporter_protocol          = optional(string, "grpc")
+    inject_exporter_endpoint   = optional(bool, true)
+    inject_resource_attributes = optional(bool, true)
+  })
+  default = {
+    enabled = false
+  }
+  validation {
+    condition     = var.dd_otlp != null
+    error_message = "The Datadog OpenTelemetry (OTLP) configuration must be defined."
+  }
+  validation {
+    condition     = try(var.dd_otlp.enabled == false, false) || try(var.dd_otlp.grpc_enabled == true, false) || try(var.dd_otlp.http_enabled == true, false)
+    error_message = "At least one of the Datadog OTLP receivers (`grpc_enabled` or `http_enabled`) must be enabled."
+  }
+  validation {
+    condition     = try(contains(["grpc", "http/protobuf"], var.dd_otlp.exporter_protocol), false)
+    error_message = "The Datadog OTLP exporter protocol must be one of 'grpc' or 'http/protobuf'."
+  }
+  validation {
+    condition     = try(var.dd_otlp.enabled == false, false) || try(var.dd_otlp.inject_exporter_endpoint == false, false) || try(var.dd_otlp.exporter_protocol == "grpc" ? var.dd_otlp.grpc_enabled : var.dd_otlp.http_enabled, false)
+    error_message = "The Datadog OTLP receiver matching `exporter_protocol` must be enabled to inject the exporter endpoint."
+  }
 }
 
 variable "dd_log_collection" {
-  description = "Configuration for Datadog Log Collection"
+  description = "Configuration for Datadog Log Collection. Linux tasks route logs through the Fluentbit log router, while Windows tasks use the `awslogs` log driver configured by `awslogs_config`. Set `fluentbit_config.is_image_digest_init` to confirm that `fluentbit_config.image_digest` is the digest of an `init` aws-for-fluent-bit image"
   type = object({
     enabled = optional(bool, false)
     fluentbit_config = optional(object({
       registry                         = optional(string, "public.ecr.aws/aws-observability/aws-for-fluent-bit")
       image_version                    = optional(string, "stable")
+      image_digest                     = optional(string)
+      is_image_digest_init             = optional(bool, false)
       cpu                              = optional(number)
       memory_limit_mib                 = optional(number)
+      memory_limit_type                = optional(string, "hard")
       is_log_router_essential          = optional(bool, false)
       is_log_router_dependency_enabled = optional(bool, false)
+      repository_credentials = optional(object({
+        credentials_parameter = string
+      }))
       log_router_health_check = optional(object({
         command      = optional(list(string))
         interval     = optional(number)
@@ -210,6 +415,11 @@ variable "dd_log_collection" {
         config_file_type  = optional(string)
         config_file_value = optional(string)
       }))
+      custom_config = optional(object({
+        s3_arn  = optional(string)
+        parsers = optional(string)
+        filters = optional(string)
+      }))
       log_driver_configuration = optional(object({
         host_endpoint = optional(string, "http-intake.logs.datadoghq.com")
         tls           = optional(bool)
@@ -233,6 +443,15 @@ variable "dd_log_collection" {
         }
       }
     )
+    awslogs_config = optional(object({
+      log_group_name    = string
+      create_log_group  = optional(bool, true)
+      retention_in_days = optional(number, 30)
+      stream_prefix     = optional(string, "ecs")
+      region            = optional(string)
+      forwarder_arn     = optional(string)
+      filter_pattern    = optional(string, "")
+    }))
   })
   default = {
     enabled = false
@@ -259,14 +478,87 @@ variable "dd_log_collection" {
     condition     = try(var.dd_log_collection.enabled == false, false) || try(var.dd_log_collection.enabled == true && var.dd_log_collection.fluentbit_config.log_driver_configuration.host_endpoint != null, false)
     error_message = "The Datadog Log Collection log driver configuration host endpoint must be defined."
   }
+  validation {
+    condition     = try(var.dd_log_collection.fluentbit_config.image_digest == null, true) || try(can(regex("^sha256:[a-f0-9]{64}$", var.dd_log_collection.fluentbit_config.image_digest)), false)
+    error_message = "If the Datadog Log Collection 'image_digest' is set, it must be a `sha256:` digest."
+  }
+  validation {
+    condition     = try(var.dd_log_collection.fluentbit_config.memory_limit_type == null, true) || try(contains(["hard", "soft"], var.dd_log_collection.fluentbit_config.memory_limit_type), false)
+    error_message = "The Datadog Log Collection 'memory_limit_type' must be one of 'hard' or 'soft'."
+  }
+  validation {
+    condition     = try(var.dd_log_collection.fluentbit_config.repository_credentials == null, true) || try(can(regex("^arn:[^:]+:secretsmanager:[^:]+:[0-9]{12}:secret:", var.dd_log_collection.fluentbit_config.repository_credentials.credentials_parameter)), false)
+    error_message = "If the Datadog Log Collection 'repository_credentials' is set, 'credentials_parameter' must be a valid Secrets Manager secret ARN."
+  }
+  validation {
+    condition     = try(var.dd_log_collection.fluentbit_config.firelens_options == null, true) || try((var.dd_log_collection.fluentbit_config.firelens_options.config_file_type == null) == (var.dd_log_collection.fluentbit_config.firelens_options.config_file_value == null), false)
+    error_message = "The Datadog Log Collection firelens 'config_file_type' and 'config_file_value' must be set together."
+  }
+  validation {
+    condition     = try(var.dd_log_collection.fluentbit_config.firelens_options.config_file_type == null, true) || try(var.dd_log_collection.fluentbit_config.firelens_options.config_file_type == "file", false)
+    error_message = "Fargate only supports the firelens 'config_file_type' `file`. Use 'custom_config.s3_arn' for a configuration file stored in S3."
+  }
+  validation {
+    condition     = try(var.dd_log_collection.fluentbit_config.custom_config == null, true) || try(var.dd_log_collection.fluentbit_config.firelens_options.config_file_value == null, true)
+    error_message = "The Datadog Log Collection 'custom_config' cannot be combined with 'firelens_options'."
+  }
+  validation {
+    condition     = try(var.dd_log_collection.fluentbit_config.custom_config == null, true) || try((var.dd_log_collection.fluentbit_config.custom_config.s3_arn != null) != (var.dd_log_collection.fluentbit_config.custom_config.parsers != null || var.dd_log_collection.fluentbit_config.custom_config.filters != null), false)
+    error_message = "The Datadog Log Collection 'custom_config' must set either 's3_arn', or 'parsers' and 'filters'."
+  }
+  validation {
+    condition     = try(var.dd_log_collection.fluentbit_config.custom_config.s3_arn == null, true) || try(can(regex("^arn:[^:]+:s3:::[^/]+/.+$", var.dd_log_collection.fluentbit_config.custom_config.s3_arn)), false)
+    error_message = "If the Datadog Log Collection 'custom_config.s3_arn' is set, it must be a valid S3 object ARN."
+  }
+  validation {
+    condition     = try(var.dd_log_collection.awslogs_config.forwarder_arn == null, true) || try(can(regex("^arn:[^:]+:lambda:[^:]+:[0-9]{12}:function:", var.dd_log_collection.awslogs_config.forwarder_arn)), false)
+    error_message = "If the Datadog Log Collection 'forwarder_arn' is set, it must be a valid Lambda function ARN."
+  }
+}
+
+variable "dd_collection" {
+  description = "Configuration for the Datadog Agent collection of ECS tasks, live processes and container images. Unset values keep the Datadog Agent defaults"
+  type = object({
+    task_collection_enabled = optional(bool, true)
+    process_collection = optional(object({
+      enabled                = optional(bool, false)
+      scrub_args             = optional(bool, true)
+      custom_sensitive_words = optional(list(string), [])
+      }),
+      {
+        enabled = false
+      }
+    )
+    container_image_enabled = optional(bool)
+    sbom_enabled            = optional(bool)
+  })
+  default = {
+    task_collection_enabled = true
+  }
+  nullable = false
+  validation {
+    condition     = try(var.dd_collection.task_collection_enabled != null && var.dd_collection.process_collection != null, false)
+    error_message = "The Datadog collection 'task_collection_enabled' and 'process_collection' must be defined."
+  }
+  validation {
+    condition     = alltrue([for word in try(var.dd_collection.process_collection.custom_sensitive_words, []) : length(regexall("[,\\s]", word)) == 0])
+    error_message = "The Datadog process collection 'custom_sensitive_words' must not contain commas or whitespace."
+  }
 }
 
 variable "dd_cws" {
   description = "Configuration for Datadog Cloud Workload Security (CWS)"
   type = object({
-    enabled          = optional(bool, false)
-    cpu              = optional(number)
-    memory_limit_mib = optional(number)
+    enabled           = optional(bool, false)
+    registry          = optional(string, "public.ecr.aws/datadog/cws-instrumentation")
+    image_version     = optional(string, "latest")
+    image_digest      = optional(string)
+    cpu               = optional(number)
+    memory_limit_mib  = optional(number)
+    memory_limit_type = optional(string, "hard")
+    repository_credentials = optional(object({
+      credentials_parameter = string
+    }))
   })
   default = {
     enabled = false
@@ -275,15 +567,130 @@ variable "dd_cws" {
     condition     = var.dd_cws != null
     error_message = "The Datadog Cloud Workload Security (CWS) configuration must be defined."
   }
+  validation {
+    condition     = try(var.dd_cws.registry != null && var.dd_cws.image_version != null, false)
+    error_message = "The Datadog Cloud Workload Security (CWS) image registry and version must be defined."
+  }
+  validation {
+    condition     = try(var.dd_cws.image_digest == null, true) || try(can(regex("^sha256:[a-f0-9]{64}$", var.dd_cws.image_digest)), false)
+    error_message = "If the Datadog Cloud Workload Security (CWS) 'image_digest' is set, it must be a `sha256:` digest."
+  }
+  validation {
+    condition     = try(contains(["hard", "soft"], var.dd_cws.memory_limit_type), false)
+    error_message = "The Datadog Cloud Workload Security (CWS) 'memory_limit_type' must be one of 'hard' or 'soft'."
+  }
+  validation {
+    condition     = try(var.dd_cws.repository_credentials == null, true) || try(can(regex("^arn:[^:]+:secretsmanager:[^:]+:[0-9]{12}:secret:", var.dd_cws.repository_credentials.credentials_parameter)), false)
+    error_message = "If the Datadog Cloud Workload Security (CWS) 'repository_credentials' is set, 'credentials_parameter' must be a valid Secrets Manager secret ARN."
+  }
+}
+
+variable "dd_proxy" {
+  description = "Outbound proxy configuration for the Datadog Agent and log router. Provide `secret_arn` instead of `https` and `http` when the proxy URL contains credentials; it must reference a Secrets Manager secret or SSM parameter containing the full proxy URL"
+  type = object({
+    https      = optional(string)
+    http       = optional(string)
+    no_proxy   = optional(list(string), [])
+    secret_arn = optional(string)
+  })
+  default = null
+  validation {
+    condition     = var.dd_proxy == null || try(var.dd_proxy.https != null || var.dd_proxy.http != null || var.dd_proxy.secret_arn != null, false)
+    error_message = "If 'dd_proxy' is set, one of 'https', 'http' or 'secret_arn' must be provided."
+  }
+  validation {
+    condition     = try(var.dd_proxy.secret_arn == null, true) || try(var.dd_proxy.https == null && var.dd_proxy.http == null, false)
+    error_message = "The Datadog proxy 'secret_arn' cannot be combined with 'https' or 'http'."
+  }
+  validation {
+    condition     = alltrue([for url in compact([try(var.dd_proxy.https, null), try(var.dd_proxy.http, null)]) : can(regex("^https?://", url))])
+    error_message = "The Datadog proxy 'https' and 'http' values must be URLs starting with `http://` or `https://`."
+  }
+  validation {
+    condition     = try(var.dd_proxy.secret_arn == null, true) || try(can(regex("^arn:[^:]+:(secretsmanager:[^:]+:[0-9]{12}:secret:|ssm:[^:]+:[0-9]{12}:parameter/)", var.dd_proxy.secret_arn)), false)
+    error_message = "If the Datadog proxy 'secret_arn' is set, it must be a valid Secrets Manager secret or SSM parameter ARN."
+  }
+}
+
+variable "dd_additional_endpoints" {
+  description = "Additional Datadog sites or organizations to dual ship metrics, traces and logs to. `api_key_secret_arn` must reference a Secrets Manager secret containing the plaintext API key of the additional organization, which is injected into the Datadog containers at runtime"
+  type = list(object({
+    site               = string
+    api_key_secret_arn = string
+    metrics_enabled    = optional(bool, true)
+    traces_enabled     = optional(bool, true)
+    logs_enabled       = optional(bool, true)
+  }))
+  default  = []
+  nullable = false
+  validation {
+    condition     = alltrue([for endpoint in var.dd_additional_endpoints : try(endpoint.site != null && endpoint.site != "", false)])
+    error_message = "Each Datadog additional endpoint must define a 'site'."
+  }
+  validation {
+    condition     = alltrue([for endpoint in var.dd_additional_endpoints : try(can(regex("^arn:[^:]+:secretsmanager:[^:]+:[0-9]{12}:secret:[^:]+$", endpoint.api_key_secret_arn)), false)])
+    error_message = "Each Datadog additional endpoint 'api_key_secret_arn' must be a valid Secrets Manager secret ARN."
+  }
+}
+
+variable "dd_fips" {
+  description = "Configuration for the Datadog FIPS Agent. Uses the `-fips` Datadog Agent image variant and requires a FIPS-capable `dd_site`. Set `is_image_digest_fips` to confirm that `dd_image_digest` is the digest of a `-fips` image"
+  type = object({
+    enabled              = optional(bool, false)
+    is_image_digest_fips = optional(bool, false)
+  })
+  default = {
+    enabled = false
+  }
+  validation {
+    condition     = var.dd_fips != null
+    error_message = "The Datadog FIPS configuration must be defined."
+  }
+}
+
+variable "dd_autodiscovery_checks" {
+  description = "Datadog Agent integration checks run through Autodiscovery, keyed by application container name and then by check name. Each check defines its `instances` and may define `init_config` and `logs`, rendered as is so that the checks and instances may have different shapes. For example, `dd_autodiscovery_checks = { redis = { redisdb = { instances = [{ host = '%%host%%', port = 6379 }] } } }`"
+  type        = any
+  default     = {}
+  nullable    = false
+  validation {
+    condition     = can(keys(var.dd_autodiscovery_checks)) && try(alltrue([for checks in values(var.dd_autodiscovery_checks) : can(keys(checks))]), false)
+    error_message = "The `dd_autodiscovery_checks` must be a map of container names to maps of check names to checks."
+  }
+  validation {
+    condition = try(alltrue(flatten([
+      for checks in values(var.dd_autodiscovery_checks) : [
+        for check in values(checks) : length(setsubtract(keys(check), ["init_config", "instances", "logs"])) == 0
+      ]
+    ])), false)
+    error_message = "Each `dd_autodiscovery_checks` check only supports the `init_config`, `instances` and `logs` attributes."
+  }
+  validation {
+    condition = try(alltrue(flatten([
+      for checks in values(var.dd_autodiscovery_checks) : [
+        for check in values(checks) : length(check.instances) > 0 && !can(keys(check.instances)) && (try(check.logs, null) == null || !can(keys(check.logs)))
+      ]
+    ])), false)
+    error_message = "Each `dd_autodiscovery_checks` check must define a non-empty list of `instances`, and `logs` must be a list."
+  }
 }
 
 ################################################################################
 # Task Definition
 ################################################################################
 
+# Note: typed as `any` since it accepts either a JSON string or a list of objects
 variable "container_definitions" {
-  description = "A list of valid [container definitions](http://docs.aws.amazon.com/AmazonECS/latest/APIReference/API_ContainerDefinition.html). Please note that you should only provide values that are part of the container definition document"
+  description = "A list of valid [container definitions](http://docs.aws.amazon.com/AmazonECS/latest/APIReference/API_ContainerDefinition.html), provided either as a JSON string or as a list of objects. Please note that you should only provide values that are part of the container definition document"
   type        = any
+  validation {
+    condition     = can([for container in try(jsondecode(var.container_definitions), var.container_definitions) : container]) && !can(keys(try(jsondecode(var.container_definitions), var.container_definitions)))
+    error_message = "The `container_definitions` must be a JSON string or a list of container definitions."
+  }
+  validation {
+    condition     = try(alltrue([for container in try(jsondecode(var.container_definitions), var.container_definitions) : try(container.name != null && container.name != "" && container.image != null && container.image != "", false)]), false)
+    error_message = "Each container definition must define a non-empty `name` and `image`."
+  }
 }
 
 variable "cpu" {
@@ -481,3 +888,50 @@ variable "volumes" {
   }))
   default = []
 }
+
+################################################################################
+# ECS Service (Optional)
+################################################################################
+
+variable "service" {
+  description = "Configuration of an optional ECS service running the task definition on Fargate. The service propagates its tags, including the Datadog module version and the Unified Service Tagging (UST) tags, to its tasks"
+  type = object({
+    name             = optional(string)
+    cluster          = string
+    desired_count    = optional(number, 1)
+    subnets          = list(string)
+    security_groups  = optional(list(string), [])
+    assign_public_ip = optional(bool, false)
+    platform_version = optional(string)
+    capacity_provider_strategy = optional(list(object({
+      capacity_provider = string
+      weight            = optional(number)
+      base              = optional(number)
+    })), [])
+    deployment_circuit_breaker = optional(object({
+      enable   = optional(bool, true)
+      rollback = optional(bool, true)
+    }), {})
+    deployment_minimum_healthy_percent = optional(number)
+    deployment_maximum_percent         = optional(number)
+    wait_for_steady_state              = optional(bool, false)
+    tags                               = optional(map(string), {})
+  })
+  default = null
+  validation {
+    condition     = var.service == null || try(var.service.cluster != null && var.service.cluster != "", false)
+    error_message = "If 'service' is set, 'cluster' must be a non-empty cluster name or ARN."
+  }
+  validation {
+    condition     = var.service == null || try(length(var.service.subnets) > 0, false)
+    error_message = "If 'service' is set, at least one subnet must be provided in 'subnets'."
+  }
+  validation {
+    condition     = var.service == null || try(alltrue([for strategy in var.service.capacity_provider_strategy : contains(["FARGATE", "FARGATE_SPOT"], strategy.capacity_provider)]), false)
+    error_message = "The service 'capacity_provider_strategy' only supports the 'FARGATE' and 'FARGATE_SPOT' capacity providers."
+  }
+  validation {
+    condition     = var.service == null || try(var.service.deployment_circuit_breaker != null, false)
+    error_message = "If 'service' is set, 'deployment_circuit_breaker' must be defined."
+  }
+}
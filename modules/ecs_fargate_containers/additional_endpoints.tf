@@ -0,0 +1,99 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# ==============================
+# Datadog Additional Endpoints (Optional)
+# ==============================
+
+# The Datadog Agent and the fluent-bit Datadog output only accept additional
+# endpoints as configuration values embedding the API keys, which ECS cannot
+# template from secrets. The API keys are injected from the provided secrets
+# at runtime instead:
+# - the Datadog init container writes them to the `dd-secrets` volume, which
+#   the Datadog Agent resolves with its `file@` secrets backend
+# - the log router expands them from its environment in the additional outputs
+
+locals {
+  is_dd_additional_endpoints = length(var.dd_additional_endpoints) > 0
+
+  dd_secrets_path               = "/opt/datadog-secrets"
+  dd_additional_api_key_envs    = [for i, endpoint in var.dd_additional_endpoints : "DD_ADDITIONAL_API_KEY_${i}"]
+  dd_additional_api_key_handles = [for i, endpoint in var.dd_additional_endpoints : "ENC[file@${local.dd_secrets_path}/additional_api_key_${i}]"]
+
+  # Grouped by URL since several organizations may share a Datadog site
+  dd_additional_metrics_endpoints = {
+    for i, endpoint in var.dd_additional_endpoints : "https://app.${endpoint.site}" => local.dd_additional_api_key_handles[i]... if endpoint.metrics_enabled
+  }
+  dd_additional_apm_endpoints = {
+    for i, endpoint in var.dd_additional_endpoints : "https://trace.agent.${endpoint.site}" => local.dd_additional_api_key_handles[i]... if endpoint.traces_enabled
+  }
+  dd_additional_logs_endpoints = [
+    for i, endpoint in var.dd_additional_endpoints : {
+      api_key     = local.dd_additional_api_key_handles[i]
+      Host        = "agent-http-intake.logs.${endpoint.site}"
+      Port        = 443
+      is_reliable = true
+    } if endpoint.logs_enabled
+  ]
+
+  dd_additional_endpoints_agent_vars = concat(
+    [
+      for pair in [
+        { key = "DD_ADDITIONAL_ENDPOINTS", value = anytrue(var.dd_additional_endpoints[*].metrics_enabled) ? jsonencode(local.dd_additional_metrics_endpoints) : null },
+        { key = "DD_APM_ADDITIONAL_ENDPOINTS", value = anytrue(var.dd_additional_endpoints[*].traces_enabled) ? jsonencode(local.dd_additional_apm_endpoints) : null },
+        { key = "DD_LOGS_CONFIG_ADDITIONAL_ENDPOINTS", value = anytrue(var.dd_additional_endpoints[*].logs_enabled) ? jsonencode(local.dd_additional_logs_endpoints) : null },
+      ] : { name = pair.key, value = pair.value } if pair.value != null
+    ],
+    local.is_dd_additional_endpoints ? [
+      {
+        name  = "DD_SECRET_BACKEND_COMMAND"
+        value = "/readsecret_multiple_providers.sh"
+      },
+    ] : [],
+  )
+
+  # API keys written to the `dd-secrets` volume by the Datadog init container
+  dd_additional_endpoints_secret_files = [
+    for i, endpoint in var.dd_additional_endpoints : {
+      env  = local.dd_additional_api_key_envs[i]
+      path = "${local.dd_secrets_path}/additional_api_key_${i}"
+    }
+  ]
+  dd_additional_endpoints_init_secrets = [
+    for i, endpoint in var.dd_additional_endpoints : {
+      name      = local.dd_additional_api_key_envs[i]
+      valueFrom = endpoint.api_key_secret_arn
+    }
+  ]
+
+  # Additional fluent-bit Datadog outputs, included in the Firelens configuration
+  dd_additional_log_router_config_path = "${local.dd_log_router_config_dir}/dd-additional-endpoints.conf"
+  is_dd_additional_log_router_outputs  = local.is_fluentbit_supported && anytrue(var.dd_additional_endpoints[*].logs_enabled)
+  dd_additional_log_router_config = join("\n", [
+    for i, endpoint in var.dd_additional_endpoints : join("\n", concat(
+      [
+        "[OUTPUT]",
+        "    Name        datadog",
+        "    Match       *",
+        "    Host        http-intake.logs.${endpoint.site}",
+        "    TLS         on",
+        "    apikey      $${${local.dd_additional_api_key_envs[i]}}",
+        "    provider    ecs",
+        "    retry_limit 2",
+        "    dd_source   ecs",
+      ],
+      var.dd_service != null ? ["    dd_service  ${var.dd_service}"] : [],
+      var.dd_tags != null ? ["    dd_tags     ${var.dd_tags}"] : [],
+      local.dd_log_proxy != null ? ["    proxy       ${local.dd_log_proxy}"] : [],
+    )) if endpoint.logs_enabled
+  ])
+
+  dd_additional_endpoints_log_router_secrets = local.is_dd_additional_log_router_outputs ? [
+    for i, endpoint in var.dd_additional_endpoints : {
+      name      = local.dd_additional_api_key_envs[i]
+      valueFrom = endpoint.api_key_secret_arn
+    } if endpoint.logs_enabled
+  ] : []
+}
@@ -0,0 +1,36 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# ==============================
+# Windows Log Collection (Optional)
+# ==============================
+
+# The Fluentbit log router is not supported on Windows, so the containers
+# write their logs to CloudWatch with the `awslogs` log driver instead.
+# The log group is forwarded to Datadog with a subscription filter to the
+# Datadog Forwarder, which is managed along with the task definition.
+
+data "aws_region" "current" {}
+
+data "aws_partition" "current" {}
+
+locals {
+  is_awslogs_enabled = var.dd_log_collection.enabled && !local.is_linux
+
+  dd_awslogs_log_group_name = try(var.dd_log_collection.awslogs_config.log_group_name, null)
+  dd_awslogs_region         = try(coalesce(var.dd_log_collection.awslogs_config.region, data.aws_region.current.name), null)
+
+  dd_awslogs_log_configuration = local.is_awslogs_enabled ? {
+    logDriver = "awslogs"
+    options = {
+      awslogs-group         = local.dd_awslogs_log_group_name
+      awslogs-region        = local.dd_awslogs_region
+      awslogs-stream-prefix = var.dd_log_collection.awslogs_config.stream_prefix
+    }
+  } : null
+
+  # Log streams are created by the task execution role
+  dd_awslogs_log_group_arn = local.is_awslogs_enabled ? "arn:${data.aws_partition.current.partition}:logs:${local.dd_awslogs_region}:*:log-group:${local.dd_awslogs_log_group_name}" : null
+}
@@ -0,0 +1,680 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# Version, Install Info, Unified Service Tagging and Datadog sockets
+module "dd_common" {
+  source = "../ecs_common"
+
+  dd_registry      = var.dd_registry
+  dd_image_version = local.dd_image_version
+  dd_image_digest  = var.dd_image_digest
+
+  dd_service = var.dd_service
+  dd_env     = var.dd_env
+  dd_version = var.dd_version
+
+  is_apm_socket_enabled = local.is_apm_socket_mount
+  is_dsd_socket_enabled = local.is_dsd_socket_mount
+}
+
+locals {
+  # AWS Resource Tags
+  tags = module.dd_common.tags
+}
+
+locals {
+
+  # Datadog API key reference (Secrets Manager secret or SSM parameter)
+  dd_api_key_value_from = try(var.dd_api_key_secret.arn, var.dd_api_key_ssm_parameter.arn, null)
+
+  is_linux               = var.runtime_platform == null || try(var.runtime_platform.operating_system_family == null, true) || try(var.runtime_platform.operating_system_family == "LINUX", true)
+  is_fluentbit_supported = var.dd_log_collection.enabled && local.is_linux
+
+  # FIPS Agent images are published with a `-fips` tag suffix
+  is_fips_enabled    = var.dd_fips.enabled == true
+  dd_image_version   = local.is_fips_enabled && !endswith(var.dd_image_version, "-fips") ? "${var.dd_image_version}-fips" : var.dd_image_version
+  is_log_tls_enabled = local.is_fips_enabled || try(var.dd_log_collection.fluentbit_config.log_driver_configuration.tls == true, false)
+
+  # Datadog sidecar images, pinned by digest when provided
+  dd_agent_image      = module.dd_common.dd_agent_image
+  dd_log_router_image = try(var.dd_log_collection.fluentbit_config.image_digest != null ? "${var.dd_log_collection.fluentbit_config.registry}@${var.dd_log_collection.fluentbit_config.image_digest}" : "${var.dd_log_collection.fluentbit_config.registry}:${var.dd_log_collection.fluentbit_config.image_version}", null)
+  dd_cws_image        = var.dd_cws.image_digest != null ? "${var.dd_cws.registry}@${var.dd_cws.image_digest}" : "${var.dd_cws.registry}:${var.dd_cws.image_version}"
+
+  # Sidecars rendered with a mutable tag instead of a digest
+  dd_unpinned_images = concat(
+    var.dd_image_digest == null ? [local.dd_agent_image] : [],
+    local.is_fluentbit_supported && try(var.dd_log_collection.fluentbit_config.image_digest, null) == null ? [local.dd_log_router_image] : [],
+    local.is_cws_supported && var.dd_cws.image_digest == null ? [local.dd_cws_image] : [],
+  )
+
+  # Datadog Firelens log configuration
+  dd_firelens_log_configuration = local.is_fluentbit_supported ? merge(
+    {
+      logDriver = "awsfirelens"
+      options = merge(
+        {
+          provider    = "ecs"
+          Name        = "datadog"
+          Host        = var.dd_log_collection.fluentbit_config.log_driver_configuration.host_endpoint
+          retry_limit = "2"
+        },
+        local.is_log_tls_enabled ? { TLS = "on" } : {},
+        var.dd_env != null ? { dd_env = var.dd_env } : {},
+        var.dd_service != null ? { dd_service = var.dd_service } : {},
+        { dd_source = "ecs" },
+        var.dd_log_collection.fluentbit_config.log_driver_configuration.message_key != null ? { dd_message_key = var.dd_log_collection.fluentbit_config.log_driver_configuration.message_key } : {},
+        var.dd_log_collection.fluentbit_config.log_driver_configuration.compress != null ? { compress = var.dd_log_collection.fluentbit_config.log_driver_configuration.compress } : {},
+        var.dd_tags != null ? { dd_tags = var.dd_tags } : {},
+        var.dd_api_key != null ? { apikey = var.dd_api_key } : {},
+        local.dd_log_proxy != null ? { proxy = local.dd_log_proxy } : {}
+      )
+    },
+    length(local.dd_firelens_secret_options) > 0 ? {
+      secretOptions = local.dd_firelens_secret_options
+    } : {}
+  ) : null
+
+  dd_firelens_secret_options = concat(
+    local.dd_api_key_value_from != null ? [
+      {
+        name      = "apikey"
+        valueFrom = local.dd_api_key_value_from
+      }
+    ] : [],
+    local.dd_proxy_value_from != null ? [
+      {
+        name      = "proxy"
+        valueFrom = local.dd_proxy_value_from
+      }
+    ] : []
+  )
+
+  # Outbound proxy, the fluent-bit Datadog output only supports a single HTTP proxy
+  # so the log router uses whichever of the configured proxies is `http://`
+  dd_proxy_value_from = try(var.dd_proxy.secret_arn, null)
+  dd_proxy_urls       = compact([try(var.dd_proxy.https, null), try(var.dd_proxy.http, null)])
+  dd_log_proxy        = try([for url in local.dd_proxy_urls : url if startswith(url, "http://")][0], null)
+
+  # Application container modifications
+  is_apm_socket_mount = var.dd_apm.enabled && var.dd_apm.socket_enabled && local.is_linux
+  is_dsd_socket_mount = var.dd_dogstatsd.enabled && var.dd_dogstatsd.socket_enabled && local.is_linux
+  is_apm_dsd_volume   = local.is_apm_socket_mount || local.is_dsd_socket_mount
+
+  # Windows named pipes replace the Unix domain sockets
+  is_apm_pipe = var.dd_apm.enabled && try(var.dd_apm.windows_pipe_name != null, false) && !local.is_linux
+  is_dsd_pipe = var.dd_dogstatsd.enabled && try(var.dd_dogstatsd.windows_pipe_name != null, false) && !local.is_linux
+
+  cws_entry_point_prefix = ["/cws-instrumentation-volume/cws-instrumentation", "trace", "--"]
+  is_cws_supported       = local.is_linux && var.dd_cws.enabled
+
+  # CWS on ECS Fargate requires a minimum Datadog Agent version, and the CWS
+  # instrumentation must not be newer than the Agent. Versions are only checked
+  # when pinned to a `major.minor.patch` tag
+  cws_min_agent_version = 7048000 # 7.48.0
+  agent_version         = try(regex("^v?([0-9]+)\\.([0-9]+)\\.([0-9]+)", var.dd_image_version), null)
+  cws_version           = try(regex("^v?([0-9]+)\\.([0-9]+)\\.([0-9]+)", var.dd_cws.image_version), null)
+  agent_version_number  = try(tonumber(local.agent_version[0]) * 1000000 + tonumber(local.agent_version[1]) * 1000 + tonumber(local.agent_version[2]), null)
+  cws_version_number    = try(tonumber(local.cws_version[0]) * 1000000 + tonumber(local.cws_version[1]) * 1000 + tonumber(local.cws_version[2]), null)
+  is_cws_agent_compatible = local.agent_version_number == null || (
+    try(local.agent_version_number >= local.cws_min_agent_version, false) &&
+    try(local.cws_version_number <= local.agent_version_number, true)
+  )
+
+  cws_mount = local.is_cws_supported ? [
+    {
+      sourceVolume  = "cws-instrumentation-volume"
+      containerPath = "/cws-instrumentation-volume"
+      readOnly      = false
+    }
+  ] : []
+
+  apm_pipe_var = local.is_apm_pipe ? [
+    {
+      name  = "DD_TRACE_PIPE_NAME"
+      value = var.dd_apm.windows_pipe_name
+    }
+  ] : []
+
+  dsd_pipe_var = local.is_dsd_pipe ? [
+    {
+      name  = "DD_DOGSTATSD_PIPE_NAME"
+      value = var.dd_dogstatsd.windows_pipe_name
+    }
+  ] : []
+
+  dsd_port_var = !local.is_dsd_socket_mount && !local.is_dsd_pipe && var.dd_dogstatsd.enabled ? [
+    {
+      name  = "DD_AGENT_HOST"
+      value = "127.0.0.1"
+    }
+  ] : []
+
+  # Tracer configuration (sampling, DBM and DSM propagation)
+  tracer_env_vars = [
+    for pair in [
+      { key = "DD_TRACE_SAMPLE_RATE", value = var.dd_apm.trace_sample_rate },
+      { key = "DD_TRACE_RATE_LIMIT", value = var.dd_apm.trace_rate_limit },
+      {
+        key = "DD_TRACE_SAMPLING_RULES"
+        value = var.dd_apm.trace_sampling_rules != null ? jsonencode([
+          for rule in var.dd_apm.trace_sampling_rules : { for k, v in rule : k => v if v != null }
+        ]) : null
+      },
+      { key = "DD_DBM_PROPAGATION_MODE", value = var.dd_apm.dbm_propagation_mode },
+      { key = "DD_DATA_STREAMS_ENABLED", value = var.dd_apm.data_streams_enabled },
+      { key = "DD_LOGS_INJECTION", value = var.dd_apm.logs_injection },
+      { key = "DD_RUNTIME_METRICS_ENABLED", value = var.dd_apm.runtime_metrics_enabled },
+    ] : { name = pair.key, value = tostring(pair.value) } if pair.value != null
+  ]
+
+  # Application Security Management (ASM) configuration
+  is_appsec_enabled = anytrue([
+    var.dd_appsec.threat_detection_enabled == true,
+    var.dd_appsec.iast_enabled == true,
+    var.dd_appsec.sca_enabled == true,
+    var.dd_appsec.blocking_enabled == true,
+  ])
+
+  appsec_env_vars = [
+    for pair in [
+      { key = "DD_APPSEC_ENABLED", value = var.dd_appsec.threat_detection_enabled },
+      { key = "DD_IAST_ENABLED", value = var.dd_appsec.iast_enabled },
+      { key = "DD_APPSEC_SCA_ENABLED", value = var.dd_appsec.sca_enabled },
+      { key = "DD_APPSEC_RULES", value = var.dd_appsec.rules_file },
+      { key = "DD_REMOTE_CONFIGURATION_ENABLED", value = var.dd_appsec.blocking_enabled == true ? true : null },
+      { key = "DD_APPSEC_HTTP_BLOCKED_TEMPLATE_HTML", value = var.dd_appsec.blocked_template_html },
+      { key = "DD_APPSEC_HTTP_BLOCKED_TEMPLATE_JSON", value = var.dd_appsec.blocked_template_json },
+    ] : { name = pair.key, value = tostring(pair.value) } if pair.value != null
+  ]
+
+  application_env_vars = concat(
+    var.dd_apm.profiling != null ? [
+      {
+        name  = "DD_PROFILING_ENABLED"
+        value = tostring(var.dd_apm.profiling)
+      }
+    ] : [],
+    var.dd_apm.trace_inferred_proxy_services != null ? [
+      {
+        name  = "DD_TRACE_INFERRED_PROXY_SERVICES_ENABLED"
+        value = tostring(var.dd_apm.trace_inferred_proxy_services)
+      }
+    ] : [],
+    local.tracer_env_vars,
+    local.appsec_env_vars,
+  )
+
+  # OpenTelemetry (OTLP) ingestion
+  is_otlp_grpc_enabled = var.dd_otlp.enabled && var.dd_otlp.grpc_enabled
+  is_otlp_http_enabled = var.dd_otlp.enabled && var.dd_otlp.http_enabled
+
+  otel_resource_attributes = join(",", concat(
+    var.dd_env != null ? ["deployment.environment=${var.dd_env}"] : [],
+    var.dd_service != null ? ["service.name=${var.dd_service}"] : [],
+    var.dd_version != null ? ["service.version=${var.dd_version}"] : [],
+  ))
+
+  otlp_env_vars = concat(
+    var.dd_otlp.enabled && var.dd_otlp.inject_exporter_endpoint ? [
+      {
+        name  = "OTEL_EXPORTER_OTLP_ENDPOINT"
+        value = var.dd_otlp.exporter_protocol == "grpc" ? "http://127.0.0.1:4317" : "http://127.0.0.1:4318"
+      },
+      {
+        name  = "OTEL_EXPORTER_OTLP_PROTOCOL"
+        value = var.dd_otlp.exporter_protocol
+      }
+    ] : [],
+    var.dd_otlp.enabled && var.dd_otlp.inject_resource_attributes && local.otel_resource_attributes != "" ? [
+      {
+        name  = "OTEL_RESOURCE_ATTRIBUTES"
+        value = local.otel_resource_attributes
+      }
+    ] : [],
+  )
+
+  # Autodiscovery integration checks rendered as docker labels
+  autodiscovery_labels = {
+    for container_name, checks in var.dd_autodiscovery_checks : container_name => {
+      "com.datadoghq.ad.checks" = jsonencode({
+        for check_name, check in checks : check_name => merge(
+          try(check.init_config, null) != null ? { init_config = check.init_config } : {},
+          { instances = check.instances },
+          try(check.logs, null) != null ? { logs = check.logs } : {},
+        )
+      })
+    }
+  }
+
+  agent_dependency = var.dd_is_datadog_dependency_enabled && try(var.dd_health_check.command != null, false) ? [
+    {
+      containerName = "datadog-agent"
+      condition     = "HEALTHY"
+    }
+  ] : []
+
+  log_router_dependency = try(var.dd_log_collection.fluentbit_config.is_log_router_dependency_enabled, false) && try(var.dd_log_collection.fluentbit_config.log_router_health_check.command != null, false) && local.dd_firelens_log_configuration != null ? [
+    {
+      containerName = "datadog-log-router"
+      condition     = "HEALTHY"
+    }
+  ] : []
+
+  cws_dependency = local.is_cws_supported ? [
+    {
+      containerName = "cws-instrumentation-init"
+      condition     = "SUCCESS"
+    }
+  ] : []
+
+  # Container definitions may be provided as a JSON string or as a list of objects
+  container_definitions = try(jsondecode(var.container_definitions), var.container_definitions)
+  container_names       = [for container in local.container_definitions : container.name]
+
+  modified_container_definitions = [
+    for container in local.container_definitions : merge(
+      container,
+      # Note: only configure CWS on container if entryPoint is set
+      {
+        # Append new environment variables to any existing ones.
+        environment = concat(
+          lookup(container, "environment", []),
+          module.dd_common.apm_dsd_socket_env_vars,
+          local.dsd_port_var,
+          local.apm_pipe_var,
+          local.dsd_pipe_var,
+          module.dd_common.ust_env_vars,
+          local.application_env_vars,
+          local.otlp_env_vars,
+        ),
+        # Append new volume mounts to any existing mountPoints.
+        mountPoints = concat(
+          lookup(container, "mountPoints", []),
+          module.dd_common.apm_dsd_mount_points,
+          local.is_cws_supported && lookup(container, "entryPoint", []) != [] ? local.cws_mount : [],
+        )
+        dependsOn = concat(
+          lookup(container, "dependsOn", []),
+          local.agent_dependency,
+          local.log_router_dependency,
+          local.is_cws_supported && lookup(container, "entryPoint", []) != [] ? local.cws_dependency : [],
+        )
+      },
+      # Only add the Autodiscovery labels to containers with configured checks
+      contains(keys(local.autodiscovery_labels), container.name) ? {
+        dockerLabels = merge(
+          lookup(container, "dockerLabels", {}),
+          local.autodiscovery_labels[container.name],
+        )
+      } : {},
+
+      # Only override the log configuration if the Datadog firelens configuration exists
+      local.dd_firelens_log_configuration != null ? {
+        logConfiguration = local.dd_firelens_log_configuration
+      } : {},
+      local.dd_awslogs_log_configuration != null ? {
+        logConfiguration = local.dd_awslogs_log_configuration
+      } : {},
+
+      # Only override CWS related configuration if the configuration is proper
+      local.is_cws_supported && lookup(container, "entryPoint", []) != [] ? {
+        entryPoint = concat(local.cws_entry_point_prefix, lookup(container, "entryPoint", []))
+      } : {},
+
+      local.is_cws_supported && lookup(container, "entryPoint", []) != [] ? {
+        # Note: SYS_PTRACE is the only linux capability available on Fargate
+        linuxParameters = {
+          capabilities = {
+            add = [
+              "SYS_PTRACE",
+            ]
+            drop = []
+          }
+        }
+      } : {},
+    )
+  ]
+
+  # Volume configuration for task
+  apm_dsd_volume = local.is_apm_dsd_volume ? [
+    {
+      name = "dd-sockets"
+    }
+  ] : []
+
+  cws_volume = local.is_cws_supported ? [
+    {
+      name = "cws-instrumentation-volume"
+    }
+  ] : []
+
+  modified_volumes = concat(
+    [for k, v in coalesce(var.volumes, []) : v],
+    local.apm_dsd_volume,
+    local.cws_volume,
+    local.dd_scratch_volumes,
+    local.dd_init_volumes,
+  )
+
+  # Datadog Agent container environment variables
+  base_env = concat(
+    [
+      {
+        name  = "ECS_FARGATE"
+        value = "true"
+      },
+      {
+        name  = "DD_ECS_TASK_COLLECTION_ENABLED"
+        value = tostring(var.dd_collection.task_collection_enabled)
+      },
+    ],
+    module.dd_common.install_info_env_vars,
+  )
+
+  # Live processes and container image collection
+  collection_vars = concat(
+    var.dd_collection.process_collection.enabled ? [
+      {
+        name  = "DD_PROCESS_CONFIG_PROCESS_COLLECTION_ENABLED"
+        value = "true"
+      },
+      {
+        name  = "DD_PROCESS_CONFIG_SCRUB_ARGS"
+        value = tostring(var.dd_collection.process_collection.scrub_args)
+      },
+    ] : [],
+    var.dd_collection.process_collection.enabled && length(var.dd_collection.process_collection.custom_sensitive_words) > 0 ? [
+      {
+        name  = "DD_PROCESS_CONFIG_CUSTOM_SENSITIVE_WORDS"
+        value = join(",", var.dd_collection.process_collection.custom_sensitive_words)
+      },
+    ] : [],
+    [
+      for pair in [
+        { key = "DD_CONTAINER_IMAGE_ENABLED", value = var.dd_collection.container_image_enabled },
+        { key = "DD_SBOM_ENABLED", value = var.dd_collection.sbom_enabled },
+        { key = "DD_SBOM_CONTAINER_IMAGE_ENABLED", value = var.dd_collection.sbom_enabled },
+      ] : { name = pair.key, value = tostring(pair.value) } if pair.value != null
+    ],
+  )
+
+  dynamic_env = [
+    for pair in [
+      { key = "DD_API_KEY", value = var.dd_api_key },
+      { key = "DD_SITE", value = var.dd_site },
+      { key = "DD_DOGSTATSD_TAG_CARDINALITY", value = var.dd_dogstatsd.dogstatsd_cardinality },
+      { key = "DD_TAGS", value = var.dd_tags },
+      { key = "DD_CLUSTER_NAME", value = var.dd_cluster_name },
+      { key = "DD_REMOTE_CONFIGURATION_ENABLED", value = var.dd_appsec.blocking_enabled == true ? "true" : null },
+    ] : { name = pair.key, value = pair.value } if pair.value != null
+  ]
+
+  origin_detection_vars = var.dd_dogstatsd.enabled && var.dd_dogstatsd.origin_detection_enabled ? [
+    {
+      name  = "DD_DOGSTATSD_ORIGIN_DETECTION"
+      value = "true"
+    },
+    {
+      name  = "DD_DOGSTATSD_ORIGIN_DETECTION_CLIENT"
+      value = "true"
+    }
+  ] : []
+
+  pipe_vars = concat(
+    local.is_apm_pipe ? [
+      {
+        name  = "DD_APM_WINDOWS_PIPE_NAME"
+        value = var.dd_apm.windows_pipe_name
+      }
+    ] : [],
+    local.is_dsd_pipe ? [
+      {
+        name  = "DD_DOGSTATSD_PIPE_NAME"
+        value = var.dd_dogstatsd.windows_pipe_name
+      }
+    ] : [],
+  )
+
+  proxy_vars = var.dd_proxy == null ? [] : [
+    for pair in [
+      { key = "DD_PROXY_HTTPS", value = var.dd_proxy.https },
+      { key = "DD_PROXY_HTTP", value = var.dd_proxy.http },
+      { key = "DD_PROXY_NO_PROXY", value = length(var.dd_proxy.no_proxy) > 0 ? join(" ", var.dd_proxy.no_proxy) : null },
+    ] : { name = pair.key, value = pair.value } if pair.value != null
+  ]
+
+  cws_vars = local.is_cws_supported ? [
+    {
+      name  = "DD_RUNTIME_SECURITY_CONFIG_ENABLED"
+      value = "true"
+    },
+    {
+      name  = "DD_RUNTIME_SECURITY_CONFIG_EBPFLESS_ENABLED"
+      value = "true"
+    }
+  ] : []
+
+  otlp_vars = concat(
+    local.is_otlp_grpc_enabled ? [
+      {
+        name  = "DD_OTLP_CONFIG_RECEIVER_PROTOCOLS_GRPC_ENDPOINT"
+        value = "0.0.0.0:4317"
+      }
+    ] : [],
+    local.is_otlp_http_enabled ? [
+      {
+        name  = "DD_OTLP_CONFIG_RECEIVER_PROTOCOLS_HTTP_ENDPOINT"
+        value = "0.0.0.0:4318"
+      }
+    ] : [],
+    var.dd_otlp.enabled && var.dd_otlp.logs_enabled ? [
+      {
+        name  = "DD_OTLP_CONFIG_LOGS_ENABLED"
+        value = "true"
+      }
+    ] : [],
+  )
+
+  otlp_port_mappings = concat(
+    local.is_otlp_grpc_enabled ? [
+      {
+        containerPort = 4317
+        hostPort      = 4317
+        protocol      = "tcp"
+      }
+    ] : [],
+    local.is_otlp_http_enabled ? [
+      {
+        containerPort = 4318
+        hostPort      = 4318
+        protocol      = "tcp"
+      }
+    ] : [],
+  )
+
+  dd_environment = var.dd_environment != null ? var.dd_environment : []
+
+  # Environment variables provided as secrets are removed to avoid duplicates
+  dd_agent_env = [
+    for env in concat(
+      local.base_env,
+      local.dynamic_env,
+      local.collection_vars,
+      local.origin_detection_vars,
+      local.pipe_vars,
+      local.cws_vars,
+      local.otlp_vars,
+      local.proxy_vars,
+      local.dd_additional_endpoints_agent_vars,
+      module.dd_common.ust_env_vars,
+      local.dd_environment,
+    ) : env if !contains(local.dd_agent_secrets[*].name, lookup(env, "name", ""))
+  ]
+
+  dd_agent_secrets = concat(
+    local.dd_api_key_value_from != null ? [
+      {
+        name      = "DD_API_KEY"
+        valueFrom = local.dd_api_key_value_from
+      }
+    ] : [],
+    local.dd_proxy_value_from != null ? [
+      {
+        name      = "DD_PROXY_HTTPS"
+        valueFrom = local.dd_proxy_value_from
+      },
+      {
+        name      = "DD_PROXY_HTTP"
+        valueFrom = local.dd_proxy_value_from
+      }
+    ] : [],
+    [for name, value_from in var.dd_secrets : { name = name, valueFrom = value_from }],
+  )
+
+  # Datadog Agent container definition
+  dd_agent_container = [
+    merge(
+      {
+        name        = "datadog-agent"
+        image       = local.dd_agent_image
+        essential   = var.dd_essential
+        environment = local.dd_agent_env
+        cpu         = local.dd_agent_cpu
+        memory      = local.dd_agent_memory
+        secrets     = local.dd_agent_secrets
+        portMappings = concat(
+          [
+            {
+              containerPort = 8125
+              hostPort      = 8125
+              protocol      = "udp"
+            },
+            {
+              containerPort = 8126
+              hostPort      = 8126
+              protocol      = "tcp"
+            }
+          ],
+          local.otlp_port_mappings,
+        ),
+        mountPoints      = concat(module.dd_common.apm_dsd_mount_points, local.dd_agent_config_mounts, local.dd_agent_scratch_mounts, local.dd_agent_secrets_mounts),
+        logConfiguration = local.dd_firelens_log_configuration,
+        dependsOn = concat(
+          try(var.dd_log_collection.fluentbit_config.is_log_router_dependency_enabled, false) && local.dd_firelens_log_configuration != null ? local.log_router_dependency : [],
+          length(local.dd_agent_config_mounts) > 0 || length(local.dd_agent_secrets_mounts) > 0 ? local.dd_init_dependency : [],
+        ),
+        systemControls = []
+        volumesFrom    = []
+      },
+      var.dd_repository_credentials == null ? {} : {
+        repositoryCredentials = {
+          credentialsParameter = var.dd_repository_credentials.credentials_parameter
+        }
+      },
+      local.dd_awslogs_log_configuration == null ? {} : {
+        logConfiguration = local.dd_awslogs_log_configuration
+      },
+      local.dd_agent_memory_reservation == null ? {} : {
+        memoryReservation = local.dd_agent_memory_reservation
+      },
+      var.dd_readonly_root_filesystem ? { readonlyRootFilesystem = true } : {},
+      try(var.dd_health_check.command == null, true) ? {} : {
+        healthCheck = {
+          command     = var.dd_health_check.command
+          interval    = var.dd_health_check.interval
+          timeout     = var.dd_health_check.timeout
+          retries     = var.dd_health_check.retries
+          startPeriod = var.dd_health_check.start_period
+        }
+      }
+    )
+  ]
+
+  # Datadog log router container definition
+  dd_log_container = local.is_fluentbit_supported ? [
+    merge(
+      {
+        name      = "datadog-log-router"
+        image     = local.dd_log_router_image
+        essential = var.dd_log_collection.fluentbit_config.is_log_router_essential
+        firelensConfiguration = {
+          type = "fluentbit"
+          options = merge(
+            {
+              enable-ecs-log-metadata = "true"
+            },
+            try(var.dd_log_collection.fluentbit_config.firelens_options.config_file_type != null, false) ? { config-file-type = var.dd_log_collection.fluentbit_config.firelens_options.config_file_type } : {},
+            try(var.dd_log_collection.fluentbit_config.firelens_options.config_file_value != null, false) ? { config-file-value = var.dd_log_collection.fluentbit_config.firelens_options.config_file_value } : {},
+            local.dd_log_router_config_file_path != null ? { config-file-type = "file", config-file-value = local.dd_log_router_config_file_path } : {}
+          )
+        }
+        cpu            = local.dd_log_router_cpu
+        memory         = local.dd_log_router_memory
+        mountPoints    = concat(local.dd_log_router_scratch_mounts, local.dd_log_router_config_mounts)
+        environment    = concat(module.dd_common.ust_env_vars, local.dd_log_router_config_env)
+        dependsOn      = length(local.dd_log_router_config_mounts) > 0 ? local.dd_init_dependency : []
+        portMappings   = []
+        systemControls = []
+        volumesFrom    = []
+      },
+      local.dd_log_router_memory_reservation == null ? {} : {
+        memoryReservation = local.dd_log_router_memory_reservation
+      },
+      var.dd_readonly_root_filesystem ? { readonlyRootFilesystem = true } : {},
+      # Fluent-bit only needs root to write to the image root filesystem, and
+      # writes its buffers to the scratch volume with a read-only root filesystem
+      var.dd_readonly_root_filesystem ? {} : { user = "0" },
+      # The additional Datadog outputs expand the API keys from the environment
+      local.is_dd_additional_log_router_outputs ? {
+        secrets = local.dd_additional_endpoints_log_router_secrets
+      } : {},
+      try(var.dd_log_collection.fluentbit_config.repository_credentials == null, true) ? {} : {
+        repositoryCredentials = {
+          credentialsParameter = var.dd_log_collection.fluentbit_config.repository_credentials.credentials_parameter
+        }
+      },
+      var.dd_log_collection.fluentbit_config.log_router_health_check.command == null ? {} : {
+        healthCheck = {
+          command     = var.dd_log_collection.fluentbit_config.log_router_health_check.command
+          interval    = var.dd_log_collection.fluentbit_config.log_router_health_check.interval
+          timeout     = var.dd_log_collection.fluentbit_config.log_router_health_check.timeout
+          retries     = var.dd_log_collection.fluentbit_config.log_router_health_check.retries
+          startPeriod = var.dd_log_collection.fluentbit_config.log_router_health_check.start_period
+        }
+      }
+    )
+  ] : []
+
+  # Datadog CWS tracer definition
+  dd_cws_container = local.is_cws_supported ? [
+    merge(
+      {
+        name           = "cws-instrumentation-init"
+        image          = local.dd_cws_image
+        cpu            = local.dd_cws_cpu
+        memory         = local.dd_cws_memory
+        user           = "0"
+        essential      = false
+        entryPoint     = []
+        command        = ["/cws-instrumentation", "setup", "--cws-volume-mount", "/cws-instrumentation-volume"]
+        mountPoints    = local.cws_mount
+        environment    = module.dd_common.ust_env_vars
+        portMappings   = []
+        systemControls = []
+        volumesFrom    = []
+      },
+      local.dd_cws_memory_reservation == null ? {} : {
+        memoryReservation = local.dd_cws_memory_reservation
+      },
+      # The CWS instrumentation still runs as root to copy its binary to the shared volume
+      var.dd_readonly_root_filesystem ? { readonlyRootFilesystem = true } : {},
+      try(var.dd_cws.repository_credentials == null, true) ? {} : {
+        repositoryCredentials = {
+          credentialsParameter = var.dd_cws.repository_credentials.credentials_parameter
+        }
+      }
+    )
+  ] : []
+}
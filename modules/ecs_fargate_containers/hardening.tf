@@ -0,0 +1,72 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# ==============================
+# Datadog Sidecar Hardening
+# ==============================
+
+# With `dd_readonly_root_filesystem`, the Datadog sidecars run with a read-only
+# root filesystem and only write to the task storage scratch volumes below.
+
+locals {
+  # The Datadog Agent writes its runtime configuration, authentication token and
+  # IPC certificate to its configuration directory, which is seeded from the
+  # image by the Datadog init container
+  dd_agent_config_seed_path = "/opt/datadog-agent-config"
+  dd_init_agent_config_mounts = var.dd_readonly_root_filesystem ? [
+    {
+      sourceVolume  = "dd-agent-config"
+      containerPath = local.dd_agent_config_seed_path
+      readOnly      = false
+    },
+  ] : []
+  dd_agent_config_mounts = var.dd_readonly_root_filesystem ? [
+    {
+      sourceVolume  = "dd-agent-config"
+      containerPath = "/etc/datadog-agent"
+      readOnly      = false
+    },
+  ] : []
+
+  # Datadog Agent run directory, temporary files, logs and s6 supervision state
+  dd_agent_scratch_mounts = var.dd_readonly_root_filesystem ? [
+    {
+      sourceVolume  = "dd-agent-run"
+      containerPath = "/opt/datadog-agent/run"
+      readOnly      = false
+    },
+    {
+      sourceVolume  = "dd-agent-tmp"
+      containerPath = "/tmp"
+      readOnly      = false
+    },
+    {
+      sourceVolume  = "dd-agent-logs"
+      containerPath = "/var/log/datadog"
+      readOnly      = false
+    },
+    {
+      sourceVolume  = "dd-agent-s6"
+      containerPath = "/var/run/s6"
+      readOnly      = false
+    },
+  ] : []
+
+  # Fluent-bit buffers
+  dd_log_router_state_path = "/var/fluent-bit/state"
+  dd_log_router_scratch_mounts = var.dd_readonly_root_filesystem && local.is_fluentbit_supported ? [
+    {
+      sourceVolume  = "dd-log-router-state"
+      containerPath = local.dd_log_router_state_path
+      readOnly      = false
+    },
+  ] : []
+
+  dd_scratch_volumes = [
+    for name in distinct(concat(local.dd_agent_scratch_mounts[*].sourceVolume, local.dd_log_router_scratch_mounts[*].sourceVolume)) : {
+      name = name
+    }
+  ]
+}
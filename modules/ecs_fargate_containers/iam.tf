@@ -0,0 +1,123 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# ==============================
+# Task Execution Role Permissions
+# ==============================
+
+# The *task execution role* needs access to the Datadog secret, SSM parameter
+# and private registry credential ARNs provided by the user, and to the log
+# group of the `awslogs` log driver on Windows
+
+locals {
+  is_execution_role_policy_required = var.dd_api_key_secret != null || var.dd_api_key_ssm_parameter != null || length(local.dd_value_from_arns) > 0 || length(local.dd_repository_credentials_arns) > 0 || local.is_dd_additional_endpoints || local.is_awslogs_enabled
+
+  # Private registry credentials of the rendered Datadog sidecars
+  dd_repository_credentials_arns = [
+    for credentials in [
+      var.dd_repository_credentials,
+      local.is_fluentbit_supported ? try(var.dd_log_collection.fluentbit_config.repository_credentials, null) : null,
+      local.is_cws_supported ? try(var.dd_cws.repository_credentials, null) : null,
+    ] : credentials.credentials_parameter if credentials != null
+  ]
+
+  # Additional secrets injected into the Datadog containers
+  dd_value_from_arns = concat(
+    values(var.dd_secrets),
+    local.dd_proxy_value_from != null ? [local.dd_proxy_value_from] : [],
+    var.dd_additional_endpoints[*].api_key_secret_arn,
+  )
+
+  # Secrets Manager `valueFrom` may reference a JSON key of the secret
+  dd_secret_arn_references = concat(
+    var.dd_api_key_secret != null ? [var.dd_api_key_secret.arn] : [],
+    [for arn in local.dd_value_from_arns : join(":", slice(split(":", arn), 0, 7)) if split(":", arn)[2] == "secretsmanager"],
+    local.dd_repository_credentials_arns,
+  )
+  dd_ssm_parameter_arn_references = concat(
+    var.dd_api_key_ssm_parameter != null ? [var.dd_api_key_ssm_parameter.arn] : [],
+    [for arn in local.dd_value_from_arns : arn if split(":", arn)[2] == "ssm"],
+  )
+
+  # Customer managed KMS keys used to encrypt the Datadog secrets,
+  # only usable through the service storing the secret
+  dd_secret_kms_keys = concat(
+    try(var.dd_api_key_secret.kms_key_arn, null) != null ? [
+      {
+        key_arn = var.dd_api_key_secret.kms_key_arn
+        service = "secretsmanager.${split(":", var.dd_api_key_secret.arn)[3]}.amazonaws.com"
+      }
+    ] : [],
+    try(var.dd_api_key_ssm_parameter.kms_key_arn, null) != null ? [
+      {
+        key_arn = var.dd_api_key_ssm_parameter.kms_key_arn
+        service = "ssm.${split(":", var.dd_api_key_ssm_parameter.arn)[3]}.amazonaws.com"
+      }
+    ] : [],
+  )
+
+  execution_role_policy_statements = concat(
+    length(local.dd_secret_arn_references) > 0 ? [
+      {
+        effect     = "Allow"
+        actions    = ["secretsmanager:GetSecretValue"]
+        resources  = distinct(local.dd_secret_arn_references)
+        conditions = []
+      }
+    ] : [],
+    length(local.dd_ssm_parameter_arn_references) > 0 ? [
+      {
+        effect     = "Allow"
+        actions    = ["ssm:GetParameters"]
+        resources  = distinct(local.dd_ssm_parameter_arn_references)
+        conditions = []
+      }
+    ] : [],
+    [
+      for key in local.dd_secret_kms_keys : {
+        effect    = "Allow"
+        actions   = ["kms:Decrypt"]
+        resources = [key.key_arn]
+        conditions = [
+          {
+            test     = "StringEquals"
+            variable = "kms:ViaService"
+            values   = [key.service]
+          }
+        ]
+      }
+    ],
+    local.is_awslogs_enabled ? [
+      {
+        effect     = "Allow"
+        actions    = ["logs:CreateLogStream", "logs:PutLogEvents"]
+        resources  = ["${local.dd_awslogs_log_group_arn}:*"]
+        conditions = []
+      }
+    ] : [],
+  )
+}
+
+# ==============================
+# Task Role Permissions
+# ==============================
+
+# The *task role* always needs permissions for the ecs_fargate check, and
+# access to the custom log router configuration file stored in S3
+
+locals {
+  task_role_policy_statements = concat([
+    {
+      effect = "Allow"
+      actions = [
+        "ecs:ListClusters",
+        "ecs:ListContainerInstances",
+        "ecs:DescribeContainerInstances"
+      ]
+      resources  = ["*"]
+      conditions = []
+    }
+  ], local.dd_custom_log_router_task_role_policy_statements)
+}
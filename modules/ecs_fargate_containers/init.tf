@@ -0,0 +1,82 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# ==============================
+# Datadog Init Container
+# ==============================
+
+# Files that ECS cannot provide to the Datadog sidecars, such as the custom
+# fluent-bit configuration, the additional endpoints API keys and the writable
+# copy of the Datadog Agent configuration directory, are written to task storage
+# volumes by an init container running the Datadog Agent image before the
+# sidecars start. The sidecar images are left unmodified.
+
+locals {
+  # Files written by the init container from its environment variables and secrets
+  dd_init_files = concat(local.dd_log_router_config_files, local.dd_additional_endpoints_secret_files)
+  dd_init_commands = concat(
+    length(local.dd_init_agent_config_mounts) > 0 ? ["cp -a /etc/datadog-agent/. ${local.dd_agent_config_seed_path}/"] : [],
+    [for file in local.dd_init_files : format("printf '%%s' \"$%s\" > %s", file.env, file.path)],
+  )
+  is_dd_init_container = length(local.dd_init_commands) > 0
+
+  dd_init_log_router_config_mounts = length(local.dd_log_router_config_files) > 0 ? [
+    {
+      sourceVolume  = "dd-log-router-config"
+      containerPath = local.dd_log_router_config_dir
+      readOnly      = false
+    },
+  ] : []
+
+  dd_init_secrets_mounts = length(local.dd_additional_endpoints_secret_files) > 0 ? [
+    {
+      sourceVolume  = "dd-secrets"
+      containerPath = local.dd_secrets_path
+      readOnly      = false
+    },
+  ] : []
+
+  # Mounted read-only by the sidecars reading the files
+  dd_log_router_config_mounts = [for mount in local.dd_init_log_router_config_mounts : merge(mount, { readOnly = true })]
+  dd_agent_secrets_mounts     = [for mount in local.dd_init_secrets_mounts : merge(mount, { readOnly = true })]
+
+  dd_init_dependency = local.is_dd_init_container ? [
+    {
+      containerName = "datadog-init"
+      condition     = "SUCCESS"
+    }
+  ] : []
+
+  dd_init_volumes = [
+    for mount in concat(local.dd_init_agent_config_mounts, local.dd_init_log_router_config_mounts, local.dd_init_secrets_mounts) : {
+      name = mount.sourceVolume
+    }
+  ]
+
+  # Datadog init container definition
+  dd_init_container = local.is_dd_init_container ? [
+    merge(
+      {
+        name           = "datadog-init"
+        image          = local.dd_agent_image
+        essential      = false
+        entryPoint     = ["/bin/sh", "-c"]
+        command        = [join(" && ", local.dd_init_commands)]
+        mountPoints    = concat(local.dd_init_agent_config_mounts, local.dd_init_log_router_config_mounts, local.dd_init_secrets_mounts)
+        environment    = local.dd_log_router_init_env
+        secrets        = local.dd_additional_endpoints_init_secrets
+        portMappings   = []
+        systemControls = []
+        volumesFrom    = []
+      },
+      var.dd_readonly_root_filesystem ? { readonlyRootFilesystem = true } : {},
+      var.dd_repository_credentials == null ? {} : {
+        repositoryCredentials = {
+          credentialsParameter = var.dd_repository_credentials.credentials_parameter
+        }
+      }
+    )
+  ] : []
+}
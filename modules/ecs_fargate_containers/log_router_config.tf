@@ -0,0 +1,69 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# ==============================
+# Custom Log Router Configuration (Optional)
+# ==============================
+
+# Fargate only supports Firelens configuration files present in the log router
+# container. Inline parsers and filters are written to the `dd-log-router-config`
+# volume by the Datadog init container, while S3 configuration files are
+# downloaded by the `init` variant of the aws-for-fluent-bit image with the
+# task role.
+
+locals {
+  dd_log_router_config_dir = "/fluent-bit/etc/datadog"
+
+  dd_custom_log_router_config          = local.is_fluentbit_supported ? try(var.dd_log_collection.fluentbit_config.custom_config, null) : null
+  dd_custom_log_router_s3_arn          = try(local.dd_custom_log_router_config.s3_arn, null)
+  dd_custom_log_router_parsers         = try(local.dd_custom_log_router_config.parsers, null)
+  dd_custom_log_router_filters         = try(local.dd_custom_log_router_config.filters, null)
+  is_dd_custom_log_router_s3_config    = local.dd_custom_log_router_s3_arn != null
+  is_dd_custom_log_router_inline       = local.dd_custom_log_router_parsers != null || local.dd_custom_log_router_filters != null
+  dd_custom_log_router_config_path     = "${local.dd_log_router_config_dir}/dd-custom.conf"
+  dd_custom_log_router_parsers_path    = "${local.dd_log_router_config_dir}/dd-custom-parsers.conf"
+  dd_custom_log_router_s3_bucket_arn   = local.is_dd_custom_log_router_s3_config ? split("/", local.dd_custom_log_router_s3_arn)[0] : null
+  is_dd_custom_log_router_init_version = try(var.dd_log_collection.fluentbit_config.image_digest != null ? var.dd_log_collection.fluentbit_config.is_image_digest_init : startswith(var.dd_log_collection.fluentbit_config.image_version, "init-"), false)
+
+  # The inline configuration includes the additional Datadog outputs since
+  # Firelens only supports a single configuration file
+  dd_custom_log_router_inline_config = join("\n", concat(
+    local.dd_custom_log_router_parsers != null ? ["[SERVICE]", "    Parsers_File ${local.dd_custom_log_router_parsers_path}", ""] : [],
+    local.dd_custom_log_router_filters != null ? [local.dd_custom_log_router_filters] : [],
+    local.is_dd_additional_log_router_outputs ? ["@INCLUDE ${local.dd_additional_log_router_config_path}"] : [],
+  ))
+
+  # Files written by the Datadog init container
+  dd_log_router_config_files = concat(
+    local.dd_custom_log_router_parsers != null ? [{ env = "DD_LOG_ROUTER_CUSTOM_PARSERS", path = local.dd_custom_log_router_parsers_path }] : [],
+    local.is_dd_custom_log_router_inline ? [{ env = "DD_LOG_ROUTER_CUSTOM_CONFIG", path = local.dd_custom_log_router_config_path }] : [],
+    local.is_dd_additional_log_router_outputs ? [{ env = "DD_ADDITIONAL_ENDPOINTS_CONFIG", path = local.dd_additional_log_router_config_path }] : [],
+  )
+  dd_log_router_config_file_path = local.is_dd_custom_log_router_inline ? local.dd_custom_log_router_config_path : local.is_dd_additional_log_router_outputs ? local.dd_additional_log_router_config_path : null
+
+  dd_log_router_init_env = concat(
+    local.dd_custom_log_router_parsers != null ? [{ name = "DD_LOG_ROUTER_CUSTOM_PARSERS", value = local.dd_custom_log_router_parsers }] : [],
+    local.is_dd_custom_log_router_inline ? [{ name = "DD_LOG_ROUTER_CUSTOM_CONFIG", value = local.dd_custom_log_router_inline_config }] : [],
+    local.is_dd_additional_log_router_outputs ? [{ name = "DD_ADDITIONAL_ENDPOINTS_CONFIG", value = local.dd_additional_log_router_config }] : [],
+  )
+
+  dd_log_router_config_env = local.is_dd_custom_log_router_s3_config ? [{ name = "aws_fluent_bit_init_s3_1", value = local.dd_custom_log_router_s3_arn }] : []
+
+  # The log router reads the S3 configuration file with the task role
+  dd_custom_log_router_task_role_policy_statements = local.is_dd_custom_log_router_s3_config ? [
+    {
+      effect     = "Allow"
+      actions    = ["s3:GetObject"]
+      resources  = [local.dd_custom_log_router_s3_arn]
+      conditions = []
+    },
+    {
+      effect     = "Allow"
+      actions    = ["s3:GetBucketLocation"]
+      resources  = [local.dd_custom_log_router_s3_bucket_arn]
+      conditions = []
+    },
+  ] : []
+}
@@ -0,0 +1,143 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+output "container_definitions" {
+  description = "The Datadog sidecars and instrumented application containers, provided as a single valid JSON document."
+  value = jsonencode(
+    concat(
+      local.dd_agent_container,
+      local.dd_log_container,
+      local.dd_cws_container,
+      local.dd_init_container,
+      [for k, v in local.modified_container_definitions : v],
+    )
+  )
+
+  # Attach any complex Datadog configuration rules (multiple variables)
+  precondition {
+    condition     = var.dd_cws.enabled == false || (var.dd_cws.enabled == true && var.dd_is_datadog_dependency_enabled == true)
+    error_message = "The Datadog Agent container dependency must be enabled for CWS to be stable. Please set `dd_is_datadog_dependency_enabled` to `true`."
+  }
+  precondition {
+    condition     = var.dd_require_image_digests == false || length(local.dd_unpinned_images) == 0
+    error_message = "All Datadog sidecar images must be pinned by digest when `dd_require_image_digests` is enabled. Set `dd_image_digest`, `dd_log_collection.fluentbit_config.image_digest` and `dd_cws.image_digest`. Unpinned images: ${join(", ", local.dd_unpinned_images)}."
+  }
+  precondition {
+    condition     = local.is_cws_supported == false || local.is_cws_agent_compatible
+    error_message = "The Datadog Agent image version is not compatible with CWS. CWS requires Datadog Agent 7.48.0 or later and a `dd_cws.image_version` that is not newer than `dd_image_version`."
+  }
+  precondition {
+    condition     = local.is_dd_auto_sizing == false || (var.cpu != null && var.memory != null)
+    error_message = "The `auto` mode of `dd_resource_sizing` requires the task `cpu` and `memory`."
+  }
+  precondition {
+    condition     = local.is_dd_auto_sizing == false || try(local.dd_sidecars_cpu <= var.cpu * (1 - var.dd_resource_sizing.min_app_share) && local.dd_sidecars_memory <= var.memory * (1 - var.dd_resource_sizing.min_app_share), false)
+    error_message = "The Datadog sidecars (${local.dd_sidecars_cpu} CPU units, ${local.dd_sidecars_memory} MiB) leave less than ${floor(var.dd_resource_sizing.min_app_share * 100 + 0.5)}% of the task CPU and memory to the application containers. Please increase the task `cpu` and `memory`, or lower `dd_resource_sizing.min_app_share`."
+  }
+  precondition {
+    condition     = local.is_linux == false || (try(var.dd_apm.windows_pipe_name == null, true) && try(var.dd_dogstatsd.windows_pipe_name == null, true))
+    error_message = "Named pipes are only supported on Windows. Please unset `dd_apm.windows_pipe_name` and `dd_dogstatsd.windows_pipe_name`, or use `socket_enabled` on Linux."
+  }
+  precondition {
+    condition     = local.is_linux || var.dd_readonly_root_filesystem == false
+    error_message = "Read-only root filesystems are not supported on Windows. Please set `dd_readonly_root_filesystem` to `false`."
+  }
+  precondition {
+    condition     = local.is_linux || local.is_dd_additional_endpoints == false
+    error_message = "Datadog additional endpoints are not supported on Windows. Please unset `dd_additional_endpoints`."
+  }
+  precondition {
+    condition     = local.is_awslogs_enabled == false || local.dd_awslogs_log_group_name != null
+    error_message = "Log collection on Windows uses the `awslogs` log driver. Please set `dd_log_collection.awslogs_config`."
+  }
+  # Must provide only one of the three Datadog API key options
+  precondition {
+    condition     = length([for source in [var.dd_api_key, var.dd_api_key_secret, var.dd_api_key_ssm_parameter] : source if source != null]) == 1
+    error_message = "You must provide only one of the three Datadog API key options: `dd_api_key`, `dd_api_key_secret` or `dd_api_key_ssm_parameter`."
+  }
+  precondition {
+    condition     = local.is_fips_enabled == false || try(contains(["ddog-gov.com"], var.dd_site), false)
+    error_message = "The Datadog FIPS Agent requires a FIPS-capable Datadog site. Please set `dd_site` to `ddog-gov.com`."
+  }
+  # The FIPS Agent natively uses FIPS-validated cryptography, the legacy
+  # FIPS proxy settings would redirect its traffic to a local proxy
+  precondition {
+    condition     = local.is_fips_enabled == false || length([for env in var.dd_environment : env if startswith(lookup(env, "name", ""), "DD_FIPS_")]) == 0
+    error_message = "The Datadog FIPS Agent does not use the FIPS proxy. Please remove the `DD_FIPS_*` variables from `dd_environment`."
+  }
+  # The `-fips` suffix cannot be applied to an image digest
+  precondition {
+    condition     = local.is_fips_enabled == false || var.dd_image_digest == null || var.dd_fips.is_image_digest_fips
+    error_message = "The Datadog FIPS Agent image cannot be selected from `dd_image_digest`. Please pin the digest of a `-fips` image and set `dd_fips.is_image_digest_fips` to `true`."
+  }
+  precondition {
+    condition     = local.is_fips_enabled == false || local.is_fluentbit_supported == false || try(endswith(var.dd_log_collection.fluentbit_config.log_driver_configuration.host_endpoint, ".${var.dd_site}"), false)
+    error_message = "The Datadog FIPS Agent requires logs to be sent to the FIPS-capable Datadog site. Please set `dd_log_collection.fluentbit_config.log_driver_configuration.host_endpoint` to an endpoint of `dd_site`."
+  }
+  precondition {
+    condition     = local.is_fluentbit_supported == false || length(local.dd_proxy_urls) == 0 || local.dd_log_proxy != null
+    error_message = "The Datadog log router only supports `http://` proxy URLs. Please set `dd_proxy.https` or `dd_proxy.http` to an `http://` URL."
+  }
+  precondition {
+    condition     = local.is_dd_custom_log_router_s3_config == false || local.is_dd_custom_log_router_init_version
+    error_message = "A custom fluent-bit configuration file stored in S3 requires the `init` variant of the aws-for-fluent-bit image. Please set `dd_log_collection.fluentbit_config.image_version` to an `init-` tag, such as `init-latest`, or pin the digest of an `init` image and set `dd_log_collection.fluentbit_config.is_image_digest_init` to `true`."
+  }
+  precondition {
+    condition     = local.is_dd_custom_log_router_s3_config == false || (local.is_dd_additional_log_router_outputs == false && var.dd_readonly_root_filesystem == false)
+    error_message = "A custom fluent-bit configuration file stored in S3 cannot be combined with Datadog additional endpoints for logs or `dd_readonly_root_filesystem`. Please use inline `parsers` and `filters` instead."
+  }
+  precondition {
+    condition     = local.is_dd_additional_log_router_outputs == false || try(var.dd_log_collection.fluentbit_config.firelens_options.config_file_value == null, true)
+    error_message = "Datadog additional endpoints for logs cannot be combined with a custom fluent-bit configuration file. Please unset `dd_log_collection.fluentbit_config.firelens_options` or disable `logs_enabled` on the additional endpoints."
+  }
+  precondition {
+    condition     = local.is_appsec_enabled == false || var.dd_apm.enabled == true
+    error_message = "Datadog APM must be enabled to use Application Security Management (ASM). Please set `dd_apm.enabled` to `true`."
+  }
+  precondition {
+    condition     = alltrue([for name in keys(var.dd_autodiscovery_checks) : contains(local.container_names, name)])
+    error_message = "All containers referenced in `dd_autodiscovery_checks` must be defined in `container_definitions`."
+  }
+}
+
+output "volumes" {
+  description = "The volumes provided in `volumes` along with the volumes required by the Datadog sidecars."
+  value       = local.modified_volumes
+}
+
+output "execution_role_policy_statements" {
+  description = "IAM policy statements (`effect`, `actions`, `resources` and `conditions`) granting the task execution role access to the Datadog secrets, if any."
+  value       = local.execution_role_policy_statements
+}
+
+output "is_execution_role_policy_required" {
+  description = "Whether the task execution role requires the `execution_role_policy_statements`. Unlike the statements, always known at plan time."
+  value       = local.is_execution_role_policy_required
+}
+
+output "task_role_policy_statements" {
+  description = "IAM policy statements (`effect`, `actions`, `resources` and `conditions`) required by the Datadog Agent on the task role."
+  value       = local.task_role_policy_statements
+}
+
+output "dd_sidecar_resources" {
+  description = "CPU units and memory (MiB) of the rendered Datadog sidecars, computed in the `auto` mode of `dd_resource_sizing`. Null for sidecars that are not rendered."
+  value       = local.dd_sidecar_resources
+}
+
+output "is_awslogs_enabled" {
+  description = "Whether the containers use the `awslogs` log driver for Windows log collection. The log group must be forwarded to Datadog, for example with a subscription filter to the Datadog Forwarder."
+  value       = local.is_awslogs_enabled
+}
+
+output "awslogs_log_configuration" {
+  description = "The `awslogs` log configuration of the containers. Null unless `is_awslogs_enabled`."
+  value       = local.dd_awslogs_log_configuration
+}
+
+output "tags" {
+  description = "Datadog tags to add to the task definition and related resources."
+  value       = local.tags
+}
@@ -0,0 +1,117 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# ==============================
+# Datadog Sidecar Sizing
+# ==============================
+
+# In `auto` mode, the Datadog sidecars reserve CPU and memory according to
+# the task size and the enabled features, unless explicitly set. Memory is
+# reserved with `memoryReservation` so the sidecars can burst when the
+# application containers leave memory available.
+
+locals {
+  is_dd_auto_sizing = var.dd_resource_sizing.mode == "auto"
+
+  # Task size tiers: small (less than 1 vCPU), medium (less than 4 vCPU) and large
+  dd_task_size_tier = try(var.cpu < 1024 ? "small" : var.cpu < 4096 ? "medium" : "large", "small")
+
+  dd_agent_base_resources = {
+    small  = { cpu = 64, memory = 256 }
+    medium = { cpu = 128, memory = 512 }
+    large  = { cpu = 256, memory = 1024 }
+  }
+
+  # Additional Datadog Agent resources for each enabled feature
+  dd_agent_feature_resources = [
+    { enabled = var.dd_apm.enabled, cpu = 32, memory = 64 },
+    { enabled = var.dd_otlp.enabled, cpu = 32, memory = 64 },
+    { enabled = local.is_cws_supported, cpu = 64, memory = 128 },
+    { enabled = length(keys(var.dd_autodiscovery_checks)) > 0, cpu = 32, memory = 64 },
+  ]
+
+  dd_log_router_base_resources = {
+    small  = { cpu = 32, memory = 64 }
+    medium = { cpu = 64, memory = 128 }
+    large  = { cpu = 128, memory = 256 }
+  }
+
+  # The CWS instrumentation only copies its binary before exiting
+  dd_cws_init_resources = { cpu = 16, memory = 32 }
+
+  dd_agent_auto_cpu    = local.dd_agent_base_resources[local.dd_task_size_tier].cpu + sum(concat([0], [for feature in local.dd_agent_feature_resources : feature.cpu if feature.enabled]))
+  dd_agent_auto_memory = local.dd_agent_base_resources[local.dd_task_size_tier].memory + sum(concat([0], [for feature in local.dd_agent_feature_resources : feature.memory if feature.enabled]))
+
+  # Explicit values always take precedence over the computed ones, and are set
+  # as a hard (`memory`) or soft (`memoryReservation`) limit
+  dd_log_router_memory_limit_mib  = try(var.dd_log_collection.fluentbit_config.memory_limit_mib, null)
+  dd_log_router_memory_limit_type = try(var.dd_log_collection.fluentbit_config.memory_limit_type, "hard")
+
+  # Computed and explicit resources of the rendered sidecars
+  dd_auto_sidecars = [
+    for sidecar in [
+      {
+        is_rendered     = true
+        cpu             = local.dd_agent_auto_cpu
+        memory          = local.dd_agent_auto_memory
+        explicit_cpu    = var.dd_cpu
+        explicit_memory = var.dd_memory_limit_mib
+      },
+      {
+        is_rendered     = local.is_fluentbit_supported
+        cpu             = local.dd_log_router_base_resources[local.dd_task_size_tier].cpu
+        memory          = local.dd_log_router_base_resources[local.dd_task_size_tier].memory
+        explicit_cpu    = try(var.dd_log_collection.fluentbit_config.cpu, null)
+        explicit_memory = local.dd_log_router_memory_limit_mib
+      },
+      {
+        is_rendered     = local.is_cws_supported
+        cpu             = local.dd_cws_init_resources.cpu
+        memory          = local.dd_cws_init_resources.memory
+        explicit_cpu    = var.dd_cws.cpu
+        explicit_memory = var.dd_cws.memory_limit_mib
+      },
+    ] : sidecar if sidecar.is_rendered
+  ]
+
+  # The computed resources are scaled down to fit in the share of the task left
+  # by `min_app_share` and the explicit values, so that the feature resources
+  # do not exceed the smallest Fargate task sizes
+  dd_auto_cpu_budget    = try(var.cpu * (1 - var.dd_resource_sizing.min_app_share) - sum(concat([0], [for sidecar in local.dd_auto_sidecars : sidecar.explicit_cpu if sidecar.explicit_cpu != null])), null)
+  dd_auto_memory_budget = try(var.memory * (1 - var.dd_resource_sizing.min_app_share) - sum(concat([0], [for sidecar in local.dd_auto_sidecars : sidecar.explicit_memory if sidecar.explicit_memory != null])), null)
+  dd_auto_cpu_total     = sum(concat([0], [for sidecar in local.dd_auto_sidecars : sidecar.cpu if sidecar.explicit_cpu == null]))
+  dd_auto_memory_total  = sum(concat([0], [for sidecar in local.dd_auto_sidecars : sidecar.memory if sidecar.explicit_memory == null]))
+  dd_auto_cpu_scale     = local.dd_auto_cpu_budget == null || local.dd_auto_cpu_total == 0 ? 1 : min(1, max(0, local.dd_auto_cpu_budget) / local.dd_auto_cpu_total)
+  dd_auto_memory_scale  = local.dd_auto_memory_budget == null || local.dd_auto_memory_total == 0 ? 1 : min(1, max(0, local.dd_auto_memory_budget) / local.dd_auto_memory_total)
+
+  dd_agent_cpu                     = local.is_dd_auto_sizing ? coalesce(var.dd_cpu, floor(local.dd_agent_auto_cpu * local.dd_auto_cpu_scale)) : var.dd_cpu
+  dd_agent_memory                  = var.dd_memory_limit_type == "hard" ? var.dd_memory_limit_mib : null
+  dd_agent_memory_reservation      = var.dd_memory_limit_mib == null ? (local.is_dd_auto_sizing ? floor(local.dd_agent_auto_memory * local.dd_auto_memory_scale) : null) : (var.dd_memory_limit_type == "soft" ? var.dd_memory_limit_mib : null)
+  dd_log_router_cpu                = local.is_dd_auto_sizing ? coalesce(try(var.dd_log_collection.fluentbit_config.cpu, null), floor(local.dd_log_router_base_resources[local.dd_task_size_tier].cpu * local.dd_auto_cpu_scale)) : try(var.dd_log_collection.fluentbit_config.cpu, null)
+  dd_log_router_memory             = local.dd_log_router_memory_limit_type == "hard" ? local.dd_log_router_memory_limit_mib : null
+  dd_log_router_memory_reservation = local.dd_log_router_memory_limit_mib == null ? (local.is_dd_auto_sizing ? floor(local.dd_log_router_base_resources[local.dd_task_size_tier].memory * local.dd_auto_memory_scale) : null) : (local.dd_log_router_memory_limit_type == "soft" ? local.dd_log_router_memory_limit_mib : null)
+  dd_cws_cpu                       = local.is_dd_auto_sizing ? coalesce(var.dd_cws.cpu, floor(local.dd_cws_init_resources.cpu * local.dd_auto_cpu_scale)) : var.dd_cws.cpu
+  dd_cws_memory                    = var.dd_cws.memory_limit_type == "hard" ? var.dd_cws.memory_limit_mib : null
+  dd_cws_memory_reservation        = var.dd_cws.memory_limit_mib == null ? (local.is_dd_auto_sizing ? floor(local.dd_cws_init_resources.memory * local.dd_auto_memory_scale) : null) : (var.dd_cws.memory_limit_type == "soft" ? var.dd_cws.memory_limit_mib : null)
+
+  # CPU and memory of the rendered Datadog sidecars, null when not set
+  dd_sidecar_resources = {
+    agent = {
+      cpu    = local.dd_agent_cpu
+      memory = try(coalesce(local.dd_agent_memory, local.dd_agent_memory_reservation), null)
+    }
+    log_router = local.is_fluentbit_supported ? {
+      cpu    = local.dd_log_router_cpu
+      memory = try(coalesce(local.dd_log_router_memory, local.dd_log_router_memory_reservation), null)
+    } : null
+    cws_init = local.is_cws_supported ? {
+      cpu    = local.dd_cws_cpu
+      memory = try(coalesce(local.dd_cws_memory, local.dd_cws_memory_reservation), null)
+    } : null
+  }
+
+  dd_sidecars_cpu    = sum(concat([0], [for sidecar in values(local.dd_sidecar_resources) : try(sidecar.cpu, 0) if try(sidecar.cpu, null) != null]))
+  dd_sidecars_memory = sum(concat([0], [for sidecar in values(local.dd_sidecar_resources) : try(sidecar.memory, 0) if try(sidecar.memory, null) != null]))
+}
@@ -0,0 +1,182 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+# ==============================
+# Task Execution Role
+# ==============================
+
+# Will edit the *task execution role* provided by the user
+# only if the Datadog secrets, SSM parameters or private
+# registry credentials require permissions. Will create one
+# when required, or when the task always requires one
+
+locals {
+  create_dd_secret_perms = var.is_execution_role_policy_required
+  edit_execution_role    = var.execution_role != null && local.create_dd_secret_perms
+  create_execution_role  = var.execution_role == null && (var.is_execution_role_required || local.create_dd_secret_perms)
+}
+
+# ==============================
+# Datadog API Key Secret Policy (Optional)
+# ==============================
+data "aws_iam_policy_document" "dd_secret_access" {
+  count = local.create_dd_secret_perms ? 1 : 0
+
+  dynamic "statement" {
+    for_each = var.execution_role_policy_statements
+
+    content {
+      effect    = statement.value.effect
+      actions   = statement.value.actions
+      resources = statement.value.resources
+
+      dynamic "condition" {
+        for_each = statement.value.conditions
+
+        content {
+          test     = condition.value.test
+          variable = condition.value.variable
+          values   = condition.value.values
+        }
+      }
+    }
+  }
+}
+
+resource "aws_iam_policy" "dd_secret_access" {
+  count  = local.create_dd_secret_perms ? 1 : 0
+  name   = "${var.name}-dd-secret-access"
+  policy = data.aws_iam_policy_document.dd_secret_access[0].json
+}
+
+# ==============================
+# Case 1: User provides existing Task Execution Role
+# ==============================
+data "aws_iam_role" "ecs_task_exec_role" {
+  count = local.edit_execution_role ? 1 : 0
+  name  = element(split("/", var.execution_role.arn), 1)
+}
+
+resource "aws_iam_role_policy_attachment" "existing_role_dd_secret" {
+  count      = local.edit_execution_role ? 1 : 0
+  role       = data.aws_iam_role.ecs_task_exec_role[0].name
+  policy_arn = aws_iam_policy.dd_secret_access[0].arn
+}
+
+# ==============================
+# Case 2: Create a Task Execution Role
+# ==============================
+resource "aws_iam_role" "new_ecs_task_execution_role" {
+  count = local.create_execution_role ? 1 : 0
+  name  = "${var.name}-ecs-task-exec-role"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect = "Allow"
+      Principal = {
+        Service = "ecs-tasks.amazonaws.com"
+      }
+      Action = "sts:AssumeRole"
+    }]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "new_ecs_task_execution_role_policy" {
+  count      = local.create_execution_role ? 1 : 0
+  role       = aws_iam_role.new_ecs_task_execution_role[0].name
+  policy_arn = "arn:aws:iam::aws:policy/service-role/AmazonECSTaskExecutionRolePolicy"
+}
+
+resource "aws_iam_role_policy_attachment" "new_role_dd_secret" {
+  count      = local.create_execution_role && local.create_dd_secret_perms ? 1 : 0
+  role       = aws_iam_role.new_ecs_task_execution_role[0].name
+  policy_arn = aws_iam_policy.dd_secret_access[0].arn
+}
+
+# ==============================
+# Task Role
+# ==============================
+
+# Will create or edit the *task role* always
+# in order to add the permissions required by the Datadog sidecars
+
+locals {
+  edit_task_role   = var.task_role != null
+  create_task_role = var.task_role == null
+}
+
+# ==============================
+# ECS Task Permissions Policy
+# ==============================
+data "aws_iam_policy_document" "dd_ecs_task_permissions" {
+  dynamic "statement" {
+    for_each = var.task_role_policy_statements
+
+    content {
+      effect    = statement.value.effect
+      actions   = statement.value.actions
+      resources = statement.value.resources
+
+      dynamic "condition" {
+        for_each = statement.value.conditions
+
+        content {
+          test     = condition.value.test
+          variable = condition.value.variable
+          values   = condition.value.values
+        }
+      }
+    }
+  }
+}
+
+resource "aws_iam_policy" "dd_ecs_task_permissions" {
+  name   = "${var.name}-dd-ecs-task-policy"
+  policy = data.aws_iam_policy_document.dd_ecs_task_permissions.json
+}
+
+# ==============================
+# Case 1: User provides existing Task Role
+# ==============================
+
+data "aws_iam_role" "ecs_task_role" {
+  count = local.edit_task_role ? 1 : 0
+  name  = element(split("/", var.task_role.arn), 1)
+}
+
+# Always attach `dd_ecs_task_permissions`
+resource "aws_iam_role_policy_attachment" "existing_role_ecs_task_permissions" {
+  count      = local.edit_task_role ? 1 : 0
+  role       = data.aws_iam_role.ecs_task_role[0].name
+  policy_arn = aws_iam_policy.dd_ecs_task_permissions.arn
+}
+
+# ==============================
+# Case 2: Create a Task Role
+# ==============================
+
+resource "aws_iam_role" "new_ecs_task_role" {
+  count = local.create_task_role ? 1 : 0
+  name  = "${var.name}-ecs-task-role"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [{
+      Effect = "Allow"
+      Principal = {
+        Service = "ecs-tasks.amazonaws.com"
+      }
+      Action = "sts:AssumeRole"
+    }]
+  })
+}
+
+# Always attach `dd_ecs_task_permissions`
+resource "aws_iam_role_policy_attachment" "new_role_ecs_task_permissions" {
+  count      = local.create_task_role ? 1 : 0
+  role       = aws_iam_role.new_ecs_task_role[0].name
+  policy_arn = aws_iam_policy.dd_ecs_task_permissions.arn
+}
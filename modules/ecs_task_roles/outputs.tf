@@ -0,0 +1,24 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+output "execution_role_arn" {
+  description = "ARN of the task execution role, either provided by the user or created by the module. Null when no task execution role is required."
+  value       = local.create_execution_role ? aws_iam_role.new_ecs_task_execution_role[0].arn : try(var.execution_role.arn, null)
+}
+
+output "task_role_arn" {
+  description = "ARN of the task role, either provided by the user or created by the module."
+  value       = local.create_task_role ? aws_iam_role.new_ecs_task_role[0].arn : var.task_role.arn
+}
+
+output "dd_secret_access_policy" {
+  description = "JSON policy document granting the task execution role access to the Datadog secrets, if any."
+  value       = try(data.aws_iam_policy_document.dd_secret_access[0].json, null)
+}
+
+output "dd_task_permissions_policy" {
+  description = "JSON policy document granting the task role the permissions required by the Datadog sidecars."
+  value       = data.aws_iam_policy_document.dd_ecs_task_permissions.json
+}
@@ -0,0 +1,82 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Datadog ECS Task Roles Configuration
+################################################################################
+
+variable "name" {
+  description = "Prefix of the names of the IAM roles and policies created by the module"
+  type        = string
+  nullable    = false
+}
+
+variable "execution_role" {
+  description = "ARN of the task execution role provided by the user, edited to attach the `execution_role_policy_statements`"
+  type = object({
+    arn = string
+  })
+  default = null
+  validation {
+    condition     = var.execution_role == null || try(var.execution_role.arn != null, false)
+    error_message = "If 'execution_role' is set, 'arn' must be a non-null string."
+  }
+}
+
+variable "is_execution_role_required" {
+  description = "Whether a task execution role is always created when `execution_role` is not provided, instead of only when `is_execution_role_policy_required` is `true`"
+  type        = bool
+  default     = false
+  nullable    = false
+}
+
+variable "is_execution_role_policy_required" {
+  description = "Whether the task execution role requires the `execution_role_policy_statements`. Must be known at plan time"
+  type        = bool
+  nullable    = false
+}
+
+variable "execution_role_policy_statements" {
+  description = "IAM policy statements (`effect`, `actions`, `resources` and `conditions`) attached to the task execution role"
+  type = list(object({
+    effect    = string
+    actions   = list(string)
+    resources = list(string)
+    conditions = list(object({
+      test     = string
+      variable = string
+      values   = list(string)
+    }))
+  }))
+  default  = []
+  nullable = false
+}
+
+variable "task_role" {
+  description = "ARN of the task role provided by the user, edited to attach the `task_role_policy_statements`"
+  type = object({
+    arn = string
+  })
+  default = null
+  validation {
+    condition     = var.task_role == null || try(var.task_role.arn != null, false)
+    error_message = "If 'task_role' is set, 'arn' must be a non-null string."
+  }
+}
+
+variable "task_role_policy_statements" {
+  description = "IAM policy statements (`effect`, `actions`, `resources` and `conditions`) attached to the task role"
+  type = list(object({
+    effect    = string
+    actions   = list(string)
+    resources = list(string)
+    conditions = list(object({
+      test     = string
+      variable = string
+      values   = list(string)
+    }))
+  }))
+  nullable = false
+}
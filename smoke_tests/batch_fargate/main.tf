@@ -0,0 +1,98 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Batch Job Definition: ECS Properties
+################################################################################
+
+# Only planned, checks the job definition ECS properties
+module "dd_batch_job" {
+  source = "../../modules/batch_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+  dd_env     = "prod"
+  dd_version = "1.0.0"
+  dd_tags    = "team:cont-p, owner:container-monitoring"
+
+  dd_cpu              = 256
+  dd_memory_limit_mib = 512
+
+  dd_log_collection = {
+    enabled = true
+    fluentbit_config = {
+      is_log_router_dependency_enabled = true
+    }
+  }
+
+  name = "${var.test_prefix}-batch-job"
+  ecs_properties = {
+    taskProperties = [
+      {
+        containers = [
+          {
+            name    = "datadog-batch-app",
+            image   = "ghcr.io/datadog/apps-tracegen:main",
+            command = ["python", "job.py"],
+            resourceRequirements = [
+              { type = "VCPU", value = "0.75" },
+              { type = "MEMORY", value = "1024" },
+            ],
+          },
+        ]
+        networkConfiguration = {
+          assignPublicIp = "ENABLED"
+        }
+      }
+    ]
+  }
+
+  retry_strategy = {
+    attempts = 2
+  }
+  timeout = {
+    attempt_duration_seconds = 3600
+  }
+}
+
+################################################################################
+# Batch Job Definition: Container Properties
+################################################################################
+
+module "dd_batch_job_container_properties" {
+  source = "../../modules/batch_fargate"
+
+  dd_api_key_secret = {
+    arn = "arn:aws:secretsmanager:us-east-1:123456789012:secret:datadog-api-key"
+  }
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  dd_apm = {
+    enabled = false
+  }
+
+  name = "${var.test_prefix}-batch-job-container-properties"
+  container_properties = jsonencode({
+    image   = "ghcr.io/datadog/apps-dogstatsd:main"
+    command = ["./run.sh"]
+    environment = [
+      { name = "APP_MODE", value = "batch" },
+    ]
+    jobRoleArn = "arn:aws:iam::123456789012:role/batch-job-role"
+    fargatePlatformConfiguration = {
+      platformVersion = "LATEST"
+    }
+    resourceRequirements = [
+      { type = "VCPU", value = "0.5" },
+      { type = "MEMORY", value = "1024" },
+    ]
+  })
+
+  tags = {
+    team = "cont-p"
+  }
+}
@@ -0,0 +1,126 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+resource "aws_ecs_cluster" "this" {
+  name = "${var.test_prefix}-ec2-cluster"
+}
+
+################################################################################
+# Datadog Agent Daemon: Bridge Network Mode with Sockets and Logs
+################################################################################
+
+# Only planned, checks the daemon task definition and service configuration
+module "dd_daemon" {
+  source = "../../modules/ecs_ec2"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_tags    = "team:cont-p, owner:container-monitoring"
+
+  dd_cluster_name = aws_ecs_cluster.this.name
+
+  dd_log_collection = {
+    enabled = true
+  }
+
+  family  = "${var.test_prefix}-daemon"
+  cluster = aws_ecs_cluster.this.name
+}
+
+################################################################################
+# Datadog Agent Daemon: Host Network Mode with Ports
+################################################################################
+
+module "dd_daemon_host" {
+  source = "../../modules/ecs_ec2"
+
+  dd_api_key_ssm_parameter = {
+    arn = "arn:aws:ssm:us-east-1:123456789012:parameter/datadog/api-key"
+  }
+  dd_site = var.dd_site
+
+  dd_apm = {
+    socket_enabled = false
+  }
+  dd_dogstatsd = {
+    socket_enabled = false
+  }
+
+  family       = "${var.test_prefix}-daemon-host"
+  network_mode = "host"
+  cluster      = aws_ecs_cluster.this.name
+  service_name = "${var.test_prefix}-datadog-agent"
+}
+
+################################################################################
+# Application Task: Sockets
+################################################################################
+
+module "dd_app_containers" {
+  source = "../../modules/ecs_ec2_containers"
+
+  dd_service = var.dd_service
+  dd_env     = "prod"
+  dd_version = "1.0.0"
+
+  container_definitions = jsonencode([
+    {
+      name      = "datadog-apm-app",
+      image     = "ghcr.io/datadog/apps-tracegen:main",
+      essential = true,
+      memory    = 256,
+    },
+  ])
+}
+
+resource "aws_ecs_task_definition" "app" {
+  family                   = "${var.test_prefix}-app"
+  requires_compatibilities = ["EC2"]
+  network_mode             = "bridge"
+  container_definitions    = module.dd_app_containers.container_definitions
+
+  dynamic "volume" {
+    for_each = module.dd_app_containers.volumes
+
+    content {
+      name      = volume.value.name
+      host_path = volume.value.host_path
+    }
+  }
+}
+
+################################################################################
+# Application Task: Host Network Mode with Ports
+################################################################################
+
+module "dd_app_containers_host" {
+  source = "../../modules/ecs_ec2_containers"
+
+  dd_service = var.dd_service
+
+  dd_apm = {
+    socket_enabled = false
+  }
+  dd_dogstatsd = {
+    socket_enabled = false
+  }
+
+  network_mode = "host"
+  container_definitions = [
+    {
+      name      = "datadog-dogstatsd-app",
+      image     = "ghcr.io/datadog/apps-dogstatsd:main",
+      essential = true,
+      memory    = 256,
+    },
+  ]
+}
+
+resource "aws_ecs_task_definition" "app_host" {
+  family                   = "${var.test_prefix}-app-host"
+  requires_compatibilities = ["EC2"]
+  network_mode             = "host"
+  container_definitions    = module.dd_app_containers_host.container_definitions
+}
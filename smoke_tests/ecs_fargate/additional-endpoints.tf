@@ -0,0 +1,55 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Task Definition: Dual shipping to additional Datadog endpoints
+################################################################################
+
+resource "aws_secretsmanager_secret" "dd_additional_api_key" {
+  name                    = "${var.test_prefix}-dd-additional-api-key"
+  recovery_window_in_days = 0
+}
+
+resource "aws_secretsmanager_secret_version" "dd_additional_api_key" {
+  secret_id     = aws_secretsmanager_secret.dd_additional_api_key.id
+  secret_string = "test-additional-api-key"
+}
+
+module "dd_task_additional_endpoints" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  dd_additional_endpoints = [
+    {
+      site               = "datadoghq.eu"
+      api_key_secret_arn = aws_secretsmanager_secret_version.dd_additional_api_key.arn
+    },
+    {
+      site               = "us5.datadoghq.com"
+      api_key_secret_arn = aws_secretsmanager_secret_version.dd_additional_api_key.arn
+      metrics_enabled    = false
+      logs_enabled       = false
+    }
+  ]
+
+  dd_log_collection = {
+    enabled = true,
+  }
+
+  family = "${var.test_prefix}-additional-endpoints"
+  container_definitions = jsonencode([
+    {
+      name : "dummy-container",
+      image : "ubuntu:latest",
+      essential : true,
+      command : ["sleep", "infinity"]
+    }
+  ])
+
+  requires_compatibilities = ["FARGATE"]
+}
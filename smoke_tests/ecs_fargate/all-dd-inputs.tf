@@ -17,6 +17,7 @@ module "dd_task_all_dd_inputs" {
   dd_tags                          = "team:cont-p, owner:container-monitoring"
   dd_essential                     = true
   dd_is_datadog_dependency_enabled = true
+  dd_memory_limit_mib              = 256
 
   dd_environment = [
     {
@@ -37,12 +38,37 @@ module "dd_task_all_dd_inputs" {
     socket_enabled                = true,
     profiling                     = true,
     trace_inferred_proxy_services = true,
+    trace_sample_rate             = 0.5,
+    trace_rate_limit              = 50,
+    trace_sampling_rules = [
+      {
+        service     = "test-service",
+        sample_rate = 1,
+      },
+    ],
+    dbm_propagation_mode    = "full",
+    data_streams_enabled    = true,
+    logs_injection          = true,
+    runtime_metrics_enabled = true,
+  }
+
+  dd_appsec = {
+    threat_detection_enabled = true,
+    iast_enabled             = true,
+    sca_enabled              = true,
+    blocking_enabled         = true,
+  }
+
+  dd_otlp = {
+    enabled = true,
   }
 
   dd_log_collection = {
     enabled = true,
     fluentbit_config = {
       is_log_router_dependency_enabled = true,
+      memory_limit_mib                 = 64,
+      memory_limit_type                = "soft",
       log_driver_configuration = {
         service_name = "dd-test"
         source_name  = "dd-test"
@@ -51,9 +77,48 @@ module "dd_task_all_dd_inputs" {
     }
   }
 
+  dd_collection = {
+    process_collection = {
+      enabled                = true,
+      custom_sensitive_words = ["*token", "sql*"],
+    },
+    container_image_enabled = true,
+    sbom_enabled            = true,
+  }
+
   dd_cws = {
-    enabled = true,
-    cpu     = 100,
+    enabled          = true,
+    cpu              = 100,
+    memory_limit_mib = 32,
+  }
+
+  dd_autodiscovery_checks = {
+    "datadog-apm-app" = {
+      http_check = {
+        instances = [
+          {
+            name = "apm-app"
+            url  = "http://%%host%%:8080"
+          }
+        ]
+      }
+      # Checks and instances with different shapes are rendered as is
+      redisdb = {
+        init_config = {}
+        instances = [
+          {
+            host = "%%host%%"
+            port = 6379
+            tags = ["role:cache", "tier:backend"]
+          },
+          {
+            host     = "%%host%%"
+            port     = 6380
+            password = "%%env_REDIS_PASSWORD%%"
+          }
+        ]
+      }
+    }
   }
 
   # Configure Task Definition
@@ -68,6 +133,9 @@ module "dd_task_all_dd_inputs" {
       name      = "datadog-apm-app",
       image     = "ghcr.io/datadog/apps-tracegen:main",
       essential = true,
+      dockerLabels = {
+        "team" = "cont-p"
+      },
     },
     {
       name      = "datadog-cws-app",
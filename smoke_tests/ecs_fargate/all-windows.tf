@@ -8,7 +8,7 @@
 ################################################################################
 
 # Tests that the Datadog agent configuration on Windows is correct
-# In particular, we are checking APM, Dogstatsd, and `ecs.fargate` metrics
+# In particular, we are checking APM, Dogstatsd, `ecs.fargate` metrics and logs
 module "dd_task_all_windows" {
   source = "../../modules/ecs_fargate"
 
@@ -24,6 +24,15 @@ module "dd_task_all_windows" {
     enabled = true
   }
 
+  dd_log_collection = {
+    enabled = true
+    awslogs_config = {
+      log_group_name    = "/ecs/${var.test_prefix}-all-windows"
+      retention_in_days = 1
+      forwarder_arn     = var.dd_forwarder_arn
+    }
+  }
+
   family = "${var.test_prefix}-all-windows"
   container_definitions = jsonencode([
     {
@@ -0,0 +1,49 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Task Definition: API Key Secret encrypted with a customer managed KMS key
+################################################################################
+
+resource "aws_kms_key" "dd_api_key" {
+  description             = "${var.test_prefix} Datadog API key secret encryption key"
+  deletion_window_in_days = 7
+}
+
+resource "aws_secretsmanager_secret" "dd_api_key" {
+  name                    = "${var.test_prefix}-dd-api-key"
+  kms_key_id              = aws_kms_key.dd_api_key.arn
+  recovery_window_in_days = 0
+}
+
+resource "aws_secretsmanager_secret_version" "dd_api_key" {
+  secret_id     = aws_secretsmanager_secret.dd_api_key.id
+  secret_string = var.dd_api_key
+}
+
+# Verifies that the task execution role can decrypt the API key secret
+# through Secrets Manager only
+module "dd_task_api_key_secret_kms" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key_secret = {
+    arn         = aws_secretsmanager_secret.dd_api_key.arn
+    kms_key_arn = aws_kms_key.dd_api_key.arn
+  }
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  family = "${var.test_prefix}-api-key-secret-kms"
+  container_definitions = jsonencode([
+    {
+      name : "dummy-container",
+      image : "ubuntu:latest",
+      essential : true,
+      command : ["sleep", "infinity"]
+    }
+  ])
+
+  requires_compatibilities = ["FARGATE"]
+}
@@ -0,0 +1,60 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Task Definition: API Key from SSM Parameter Store
+################################################################################
+
+resource "aws_ssm_parameter" "dd_api_key" {
+  name  = "/${var.test_prefix}/dd-api-key"
+  type  = "SecureString"
+  value = var.dd_api_key
+}
+
+resource "aws_ssm_parameter" "dd_app_key" {
+  name  = "/${var.test_prefix}/dd-app-key"
+  type  = "SecureString"
+  value = "test-app-key"
+}
+
+# Verifies that the API key is injected from SSM into the agent and log router,
+# that additional agent secrets are injected from SSM
+# and that the task execution role is created with access to the parameters
+module "dd_task_api_key_ssm" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key_ssm_parameter = {
+    arn = aws_ssm_parameter.dd_api_key.arn
+  }
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  dd_secrets = {
+    DD_APP_KEY = aws_ssm_parameter.dd_app_key.arn
+  }
+
+  dd_environment = [
+    {
+      name  = "DD_APP_KEY",
+      value = "overwritten-by-secret",
+    },
+  ]
+
+  dd_log_collection = {
+    enabled = true,
+  }
+
+  family = "${var.test_prefix}-api-key-ssm"
+  container_definitions = jsonencode([
+    {
+      name : "dummy-container",
+      image : "ubuntu:latest",
+      essential : true,
+      command : ["sleep", "infinity"]
+    }
+  ])
+
+  requires_compatibilities = ["FARGATE"]
+}
@@ -0,0 +1,73 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Task Definition: Automatic Sidecar Sizing
+################################################################################
+
+# Tests that the Datadog sidecars are sized from the task size and the enabled
+# features, and that explicit sizes take precedence over the computed ones
+module "dd_task_auto_sizing" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  dd_resource_sizing = {
+    mode          = "auto"
+    min_app_share = 0.6
+  }
+
+  dd_apm = {
+    enabled = true
+  }
+
+  dd_log_collection = {
+    enabled = true
+    fluentbit_config = {
+      cpu = 96
+    }
+  }
+
+  family = "${var.test_prefix}-auto-sizing"
+  container_definitions = jsonencode([
+    {
+      name      = "datadog-apm-app",
+      image     = "ghcr.io/datadog/apps-tracegen:main",
+      essential = true,
+    },
+  ])
+  cpu    = 1024
+  memory = 2048
+}
+
+# Tests that the computed sizes are scaled down to fit the smallest Fargate task size
+module "dd_task_auto_sizing_small" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  dd_resource_sizing = {
+    mode = "auto"
+  }
+
+  dd_log_collection = {
+    enabled = true
+  }
+
+  family = "${var.test_prefix}-auto-sizing-small"
+  container_definitions = jsonencode([
+    {
+      name      = "datadog-apm-app",
+      image     = "ghcr.io/datadog/apps-tracegen:main",
+      essential = true,
+    },
+  ])
+  cpu    = 256
+  memory = 512
+}
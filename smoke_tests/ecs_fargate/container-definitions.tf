@@ -0,0 +1,68 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Task Definition: Container Definitions Input Styles
+################################################################################
+
+# Checks that container definitions provided as a JSON string
+# and as a list of objects yield the same task definition
+module "dd_task_container_definitions_json" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  family = "${var.test_prefix}-container-definitions-json"
+  container_definitions = jsonencode([
+    {
+      name      = "datadog-dogstatsd-app",
+      image     = "ghcr.io/datadog/apps-dogstatsd:main",
+      essential = false,
+      environment = [
+        {
+          name  = "APP_MODE",
+          value = "smoke",
+        },
+      ],
+    },
+    {
+      name      = "datadog-apm-app",
+      image     = "ghcr.io/datadog/apps-tracegen:main",
+      essential = true,
+    },
+  ])
+  requires_compatibilities = ["FARGATE"]
+}
+
+module "dd_task_container_definitions_list" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  family = "${var.test_prefix}-container-definitions-list"
+  container_definitions = [
+    {
+      name      = "datadog-dogstatsd-app",
+      image     = "ghcr.io/datadog/apps-dogstatsd:main",
+      essential = false,
+      environment = [
+        {
+          name  = "APP_MODE",
+          value = "smoke",
+        },
+      ],
+    },
+    {
+      name      = "datadog-apm-app",
+      image     = "ghcr.io/datadog/apps-tracegen:main",
+      essential = true,
+    },
+  ]
+  requires_compatibilities = ["FARGATE"]
+}
@@ -0,0 +1,47 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Container Definitions: Render Only
+################################################################################
+
+# Checks that the render-only submodule produces the same container definitions
+# as the ecs_fargate module did before the containers were extracted to it
+module "dd_containers" {
+  source = "../../modules/ecs_fargate_containers"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+  dd_env     = "prod"
+  dd_version = "1.0.0"
+  dd_tags    = "team:cont-p, owner:container-monitoring"
+
+  dd_is_datadog_dependency_enabled = true
+
+  dd_log_collection = {
+    enabled = true,
+  }
+
+  # Image of the CWS instrumentation before it was configurable
+  dd_cws = {
+    enabled  = true,
+    registry = "datadog/cws-instrumentation"
+  }
+
+  container_definitions = jsonencode([
+    {
+      name       = "datadog-apm-app",
+      image      = "ghcr.io/datadog/apps-tracegen:main",
+      essential  = true,
+      entryPoint = ["/usr/bin/tracegen"],
+    },
+  ])
+  volumes = [
+    {
+      name = "app-storage"
+    }
+  ]
+}
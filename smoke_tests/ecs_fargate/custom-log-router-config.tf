@@ -0,0 +1,76 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Task Definition: Custom Log Router Configuration
+################################################################################
+
+# Tests that inline parsers and filters are written to the log router before
+# starting fluent-bit
+module "dd_task_custom_log_router_config" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  dd_log_collection = {
+    enabled = true
+    fluentbit_config = {
+      custom_config = {
+        parsers = <<-EOT
+          [PARSER]
+              Name   app_json
+              Format json
+        EOT
+        filters = <<-EOT
+          [FILTER]
+              Name     parser
+              Match    *
+              Key_Name log
+              Parser   app_json
+        EOT
+      }
+    }
+  }
+
+  family = "${var.test_prefix}-custom-log-router-config"
+  container_definitions = jsonencode([
+    {
+      name      = "datadog-dogstatsd-app",
+      image     = "ghcr.io/datadog/apps-dogstatsd:main",
+      essential = true,
+    },
+  ])
+}
+
+# Tests that a configuration file stored in S3 uses the init image and grants
+# the task role access to the file
+module "dd_task_custom_log_router_config_s3" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  dd_log_collection = {
+    enabled = true
+    fluentbit_config = {
+      image_version = "init-latest"
+      custom_config = {
+        s3_arn = "arn:aws:s3:::${var.test_prefix}-fluent-bit/config/extra.conf"
+      }
+    }
+  }
+
+  family = "${var.test_prefix}-custom-log-router-config-s3"
+  container_definitions = jsonencode([
+    {
+      name      = "datadog-dogstatsd-app",
+      image     = "ghcr.io/datadog/apps-dogstatsd:main",
+      essential = true,
+    },
+  ])
+}
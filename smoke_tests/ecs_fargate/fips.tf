@@ -0,0 +1,43 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Task Definition: FIPS Agent reporting to a FIPS-capable Datadog site
+################################################################################
+
+module "dd_task_fips" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = "ddog-gov.com"
+  dd_service = var.dd_service
+
+  dd_image_version = "7.65.0"
+
+  dd_fips = {
+    enabled = true
+  }
+
+  dd_log_collection = {
+    enabled = true,
+    fluentbit_config = {
+      log_driver_configuration = {
+        host_endpoint = "http-intake.logs.ddog-gov.com"
+      }
+    }
+  }
+
+  family = "${var.test_prefix}-fips"
+  container_definitions = jsonencode([
+    {
+      name : "dummy-container",
+      image : "ubuntu:latest",
+      essential : true,
+      command : ["sleep", "infinity"]
+    }
+  ])
+
+  requires_compatibilities = ["FARGATE"]
+}
@@ -0,0 +1,45 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Task Definition: Datadog sidecar images pinned by digest
+################################################################################
+
+module "dd_task_image_digests" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  dd_is_datadog_dependency_enabled = true
+  dd_require_image_digests         = true
+
+  dd_image_digest = "sha256:4ec2ad7b23e2e7b3b1c3a8f8e6c1b49c5a3a4f1b1d1d3e0e7a1c6b2b8f4a9d01"
+
+  dd_log_collection = {
+    enabled = true
+    fluentbit_config = {
+      image_digest = "sha256:7b5b0c0f1f2a9e3d4c6b8a7f5e1d2c3b4a5968778695a4b3c2d1e0f9a8b7c602"
+    }
+  }
+
+  dd_cws = {
+    enabled      = true
+    image_digest = "sha256:a1b2c3d4e5f60718293a4b5c6d7e8f90112233445566778899aabbccddeeff03"
+  }
+
+  family = "${var.test_prefix}-image-digests"
+  container_definitions = jsonencode([
+    {
+      name : "dummy-container",
+      image : "ubuntu:latest",
+      essential : true,
+      entryPoint : ["/usr/bin/bash", "-c", "sleep infinity"],
+    }
+  ])
+
+  requires_compatibilities = ["FARGATE"]
+}
@@ -1,3 +1,7 @@
+output "additional-endpoints" {
+  value = module.dd_task_additional_endpoints
+}
+
 output "all-dd-disabled" {
   value = module.dd_task_all_dd_disabled
 }
@@ -18,14 +22,83 @@ output "all-windows" {
   value = module.dd_task_all_windows
 }
 
+output "api-key-secret-kms" {
+  value = module.dd_task_api_key_secret_kms
+}
+
+output "api-key-ssm" {
+  value = module.dd_task_api_key_ssm
+}
+
 output "apm-dsd-tcp-udp" {
   value = module.dd_task_apm_dsd_tcp_udp
 }
 
+output "auto-sizing" {
+  value = module.dd_task_auto_sizing
+}
+
+output "auto-sizing-small" {
+  value = module.dd_task_auto_sizing_small
+}
+
+output "container-definitions-json" {
+  value = module.dd_task_container_definitions_json
+}
+
+output "container-definitions-list" {
+  value = module.dd_task_container_definitions_list
+}
+
+output "containers-only" {
+  value     = module.dd_containers
+  sensitive = true
+}
+
+output "custom-log-router-config" {
+  value = module.dd_task_custom_log_router_config
+}
+
+output "custom-log-router-config-s3" {
+  value = module.dd_task_custom_log_router_config_s3
+}
+
 output "cws-only" {
   value = module.dd_task_cws_only
 }
 
+output "fips" {
+  value = module.dd_task_fips
+}
+
+output "image-digests" {
+  value = module.dd_task_image_digests
+}
+
 output "logging-only" {
   value = module.dd_task_logging_only
 }
+
+output "private-registry" {
+  value = module.dd_task_private_registry
+}
+
+output "proxy" {
+  value = module.dd_task_proxy
+}
+
+output "proxy-secret" {
+  value = module.dd_task_proxy_secret
+}
+
+output "readonly-root-filesystem" {
+  value = module.dd_task_readonly_root_filesystem
+}
+
+output "region" {
+  value = data.aws_region.current.name
+}
+
+output "windows-named-pipes" {
+  value = module.dd_task_windows_named_pipes
+}
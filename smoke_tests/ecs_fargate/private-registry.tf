@@ -0,0 +1,66 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Task Definition: Datadog sidecar images pulled from a private registry
+################################################################################
+
+resource "aws_secretsmanager_secret" "dd_registry_credentials" {
+  name                    = "${var.test_prefix}-dd-registry-credentials"
+  recovery_window_in_days = 0
+}
+
+resource "aws_secretsmanager_secret" "dd_log_router_registry_credentials" {
+  name                    = "${var.test_prefix}-dd-log-router-registry-credentials"
+  recovery_window_in_days = 0
+}
+
+module "dd_task_private_registry" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  dd_is_datadog_dependency_enabled = true
+
+  dd_registry      = "docker.io/datadog/agent"
+  dd_image_version = "7.60.0"
+  dd_repository_credentials = {
+    credentials_parameter = aws_secretsmanager_secret.dd_registry_credentials.arn
+  }
+
+  dd_log_collection = {
+    enabled = true
+    fluentbit_config = {
+      registry = "docker.io/amazon/aws-for-fluent-bit"
+      repository_credentials = {
+        credentials_parameter = aws_secretsmanager_secret.dd_log_router_registry_credentials.arn
+      }
+    }
+  }
+
+  # Shares the Datadog Agent registry credentials
+  dd_cws = {
+    enabled       = true
+    registry      = "docker.io/datadog/cws-instrumentation"
+    image_version = "7.60.0"
+    repository_credentials = {
+      credentials_parameter = aws_secretsmanager_secret.dd_registry_credentials.arn
+    }
+  }
+
+  family = "${var.test_prefix}-private-registry"
+  container_definitions = jsonencode([
+    {
+      name : "dummy-container",
+      image : "ubuntu:latest",
+      essential : true,
+      entryPoint : ["/usr/bin/bash", "-c", "sleep infinity"],
+    }
+  ])
+
+  requires_compatibilities = ["FARGATE"]
+}
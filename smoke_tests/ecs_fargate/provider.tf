@@ -1,3 +1,5 @@
 provider "aws" {
   region = "us-east-1"
 }
+
+data "aws_region" "current" {}
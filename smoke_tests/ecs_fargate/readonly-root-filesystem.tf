@@ -0,0 +1,42 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Task Definition: Read-Only Root Filesystem
+################################################################################
+
+# Tests that the Datadog sidecars run with a read-only root filesystem and
+# write to dedicated scratch volumes
+module "dd_task_readonly_root_filesystem" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key                       = var.dd_api_key
+  dd_site                          = var.dd_site
+  dd_service                       = var.dd_service
+  dd_is_datadog_dependency_enabled = true
+  dd_readonly_root_filesystem      = true
+
+  dd_apm = {
+    enabled        = true
+    socket_enabled = true
+  }
+
+  dd_log_collection = {
+    enabled = true
+  }
+
+  dd_cws = {
+    enabled = true
+  }
+
+  family = "${var.test_prefix}-readonly-root-filesystem"
+  container_definitions = jsonencode([
+    {
+      name      = "datadog-apm-app",
+      image     = "ghcr.io/datadog/apps-tracegen:main",
+      essential = true,
+    },
+  ])
+}
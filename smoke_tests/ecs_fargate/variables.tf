@@ -25,3 +25,9 @@ variable "test_prefix" {
   type        = string
   default     = "terraform-test"
 }
+
+variable "dd_forwarder_arn" {
+  description = "Datadog Forwarder Lambda ARN used to forward the Windows task logs"
+  type        = string
+  default     = null
+}
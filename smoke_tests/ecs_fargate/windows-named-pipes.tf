@@ -0,0 +1,47 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Task Definition: Windows Named Pipes
+################################################################################
+
+# Tests that APM and DogStatsD use named pipes instead of TCP/UDP on Windows
+module "dd_task_windows_named_pipes" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  dd_apm = {
+    enabled           = true
+    windows_pipe_name = "datadog-apm"
+  }
+
+  dd_dogstatsd = {
+    enabled           = true
+    windows_pipe_name = "datadog-dogstatsd"
+  }
+
+  family = "${var.test_prefix}-windows-named-pipes"
+  container_definitions = jsonencode([
+    {
+      name      = "datadog-dogstatsd-app",
+      image     = "ghcr.io/datadog/apps-dogstatsd:main",
+      essential = false,
+    },
+    {
+      name      = "datadog-apm-app",
+      image     = "ghcr.io/datadog/apps-tracegen:main",
+      essential = true,
+    },
+  ])
+  cpu    = 1024
+  memory = 2048
+  runtime_platform = {
+    cpu_architecture        = "X86_64"
+    operating_system_family = "WINDOWS_SERVER_2022_CORE"
+  }
+}
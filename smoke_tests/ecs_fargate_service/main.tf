@@ -0,0 +1,81 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# ECS Service: Fargate Launch Type
+################################################################################
+
+# Only planned, checks the service configuration and tag propagation
+module "dd_task_service" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+  dd_env     = "prod"
+  dd_version = "1.0.0"
+
+  family = "${var.test_prefix}-service"
+  container_definitions = jsonencode([
+    {
+      name      = "datadog-apm-app",
+      image     = "ghcr.io/datadog/apps-tracegen:main",
+      essential = true,
+    },
+  ])
+  requires_compatibilities = ["FARGATE"]
+
+  service = {
+    cluster         = "${var.test_prefix}-cluster"
+    desired_count   = 2
+    subnets         = ["subnet-0123456789abcdef0", "subnet-0fedcba9876543210"]
+    security_groups = ["sg-0123456789abcdef0"]
+    tags = {
+      team = "cont-p"
+    }
+  }
+}
+
+################################################################################
+# ECS Service: Capacity Providers
+################################################################################
+
+module "dd_task_service_spot" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  family = "${var.test_prefix}-service-spot"
+  container_definitions = jsonencode([
+    {
+      name      = "datadog-apm-app",
+      image     = "ghcr.io/datadog/apps-tracegen:main",
+      essential = true,
+    },
+  ])
+  requires_compatibilities = ["FARGATE"]
+
+  service = {
+    name    = "${var.test_prefix}-spot"
+    cluster = "${var.test_prefix}-cluster"
+    subnets = ["subnet-0123456789abcdef0"]
+    capacity_provider_strategy = [
+      {
+        capacity_provider = "FARGATE_SPOT"
+        weight            = 3
+      },
+      {
+        capacity_provider = "FARGATE"
+        weight            = 1
+        base              = 1
+      },
+    ]
+    deployment_circuit_breaker = {
+      rollback = false
+    }
+  }
+}
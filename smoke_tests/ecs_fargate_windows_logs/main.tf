@@ -0,0 +1,41 @@
+# Unless explicitly stated otherwise all files in this repository are licensed
+# under the Apache License Version 2.0.
+# This product includes software developed at Datadog (https://www.datadoghq.com/).
+# Copyright 2025-present Datadog, Inc.
+
+################################################################################
+# Windows Log Collection: Datadog Forwarder
+################################################################################
+
+# Only planned, checks the log group subscription to the Datadog Forwarder
+module "dd_task_windows_forwarder" {
+  source = "../../modules/ecs_fargate"
+
+  dd_api_key = var.dd_api_key
+  dd_site    = var.dd_site
+  dd_service = var.dd_service
+
+  dd_log_collection = {
+    enabled = true
+    awslogs_config = {
+      log_group_name = "/ecs/${var.test_prefix}-windows-forwarder"
+      forwarder_arn  = "arn:aws:lambda:us-east-1:123456789012:function:datadog-forwarder"
+      filter_pattern = "-DEBUG"
+    }
+  }
+
+  family = "${var.test_prefix}-windows-forwarder"
+  container_definitions = jsonencode([
+    {
+      name      = "datadog-apm-app",
+      image     = "ghcr.io/datadog/apps-tracegen:main",
+      essential = true,
+    },
+  ])
+  cpu    = 1024
+  memory = 2048
+  runtime_platform = {
+    cpu_architecture        = "X86_64"
+    operating_system_family = "WINDOWS_SERVER_2022_CORE"
+  }
+}
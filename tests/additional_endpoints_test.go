@@ -0,0 +1,83 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// TestAdditionalEndpoints tests the task definition dual shipping to additional Datadog endpoints
+func (s *ECSFargateSuite) TestAdditionalEndpoints() {
+	log.Println("TestAdditionalEndpoints: Running test...")
+
+	// Retrieve the task output for the "additional-endpoints" module
+	var containers []types.ContainerDefinition
+	task := terraform.OutputMap(s.T(), s.terraformOptions, "additional-endpoints")
+	s.Equal(s.testPrefix+"-additional-endpoints", task["family"], "Unexpected task family name")
+
+	err := json.Unmarshal([]byte(task["container_definitions"]), &containers)
+	s.NoError(err, "Failed to parse container definitions")
+
+	// Test Agent Container resolves the API keys written by the init container
+	agentContainer, found := GetContainer(containers, "datadog-agent")
+	s.True(found, "Container datadog-agent not found in definitions")
+	s.Empty(agentContainer.Secrets, "The additional API keys should not be injected into datadog-agent")
+	AssertEnvVars(s.T(), agentContainer, map[string]string{
+		"DD_ADDITIONAL_ENDPOINTS":             `{"https://app.datadoghq.eu":["ENC[file@/opt/datadog-secrets/additional_api_key_0]"]}`,
+		"DD_APM_ADDITIONAL_ENDPOINTS":         `{"https://trace.agent.datadoghq.eu":["ENC[file@/opt/datadog-secrets/additional_api_key_0]"],"https://trace.agent.us5.datadoghq.com":["ENC[file@/opt/datadog-secrets/additional_api_key_1]"]}`,
+		"DD_LOGS_CONFIG_ADDITIONAL_ENDPOINTS": `[{"Host":"agent-http-intake.logs.datadoghq.eu","Port":443,"api_key":"ENC[file@/opt/datadog-secrets/additional_api_key_0]","is_reliable":true}]`,
+		"DD_SECRET_BACKEND_COMMAND":           "/readsecret_multiple_providers.sh",
+	})
+	AssertMountPoint(s.T(), agentContainer, types.MountPoint{SourceVolume: aws.String("dd-secrets"), ContainerPath: aws.String("/opt/datadog-secrets"), ReadOnly: aws.Bool(true)})
+	s.Contains(agentContainer.DependsOn, types.ContainerDependency{ContainerName: aws.String("datadog-init"), Condition: types.ContainerConditionSuccess}, "datadog-agent should wait for datadog-init")
+
+	// Test Init Container writes the API keys and the additional log router outputs
+	initContainer, found := GetContainer(containers, "datadog-init")
+	s.True(found, "Container datadog-init not found in definitions")
+	s.False(*initContainer.Essential, "datadog-init should not be essential")
+	s.Equal([]string{"/bin/sh", "-c"}, initContainer.EntryPoint, "Unexpected entrypoint for datadog-init")
+	s.Equal(1, len(initContainer.Command), "Expected a single command for datadog-init")
+	s.Contains(initContainer.Command[0], `"$DD_ADDITIONAL_API_KEY_0" > /opt/datadog-secrets/additional_api_key_0`, "datadog-init should write the first API key")
+	s.Contains(initContainer.Command[0], `"$DD_ADDITIONAL_API_KEY_1" > /opt/datadog-secrets/additional_api_key_1`, "datadog-init should write the second API key")
+	s.Contains(initContainer.Command[0], `"$DD_ADDITIONAL_ENDPOINTS_CONFIG" > /fluent-bit/etc/datadog/dd-additional-endpoints.conf`, "datadog-init should write the additional outputs")
+	s.Equal(2, len(initContainer.Secrets), "Expected a secret per additional endpoint in datadog-init")
+	apiKeySecretArn := *initContainer.Secrets[0].ValueFrom
+	s.Contains(apiKeySecretArn, ":secret:"+s.testPrefix+"-dd-additional-api-key", "Unexpected secret for datadog-init")
+	for _, secret := range initContainer.Secrets {
+		s.Equal(apiKeySecretArn, *secret.ValueFrom, "Unexpected secret for %s", *secret.Name)
+	}
+
+	additionalConfig, found := GetEnvVar(initContainer, "DD_ADDITIONAL_ENDPOINTS_CONFIG")
+	s.True(found, "DD_ADDITIONAL_ENDPOINTS_CONFIG not found in datadog-init container")
+	s.Contains(additionalConfig, "Host        http-intake.logs.datadoghq.eu", "Unexpected additional output host")
+	s.Contains(additionalConfig, "apikey      ${DD_ADDITIONAL_API_KEY_0}", "The additional output should expand the API key from the environment")
+	s.NotContains(additionalConfig, "us5.datadoghq.com", "Logs are disabled for the second endpoint")
+
+	// Test Log Router Container reads the additional outputs from the init volume
+	logRouterContainer, found := GetContainer(containers, "datadog-log-router")
+	s.True(found, "Container datadog-log-router not found in definitions")
+	s.Equal("file", logRouterContainer.FirelensConfiguration.Options["config-file-type"], "Unexpected firelens config file type")
+	s.Equal("/fluent-bit/etc/datadog/dd-additional-endpoints.conf", logRouterContainer.FirelensConfiguration.Options["config-file-value"], "Unexpected firelens config file")
+	s.Empty(logRouterContainer.EntryPoint, "The log router entrypoint should not be overridden")
+	AssertMountPoint(s.T(), logRouterContainer, types.MountPoint{SourceVolume: aws.String("dd-log-router-config"), ContainerPath: aws.String("/fluent-bit/etc/datadog"), ReadOnly: aws.Bool(true)})
+	s.Equal(1, len(logRouterContainer.Secrets), "Expected a single secret in datadog-log-router")
+	s.Equal("DD_ADDITIONAL_API_KEY_0", *logRouterContainer.Secrets[0].Name, "Unexpected secret name for datadog-log-router")
+	s.Equal(apiKeySecretArn, *logRouterContainer.Secrets[0].ValueFrom, "Unexpected secret for datadog-log-router")
+
+	// Test the rendered execution role policy
+	var policy PolicyDocument
+	err = json.Unmarshal([]byte(task["dd_secret_access_policy"]), &policy)
+	s.NoError(err, "Failed to parse the Datadog secret access policy")
+
+	secretStatement, found := GetPolicyStatement(policy, "secretsmanager:GetSecretValue")
+	s.True(found, "secretsmanager:GetSecretValue statement not found in policy")
+	s.Equal(StringOrSlice{apiKeySecretArn}, secretStatement.Resource, "Secret access should be scoped to the additional API key secret")
+}
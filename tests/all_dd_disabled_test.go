@@ -52,6 +52,13 @@ func (s *ECSFargateSuite) TestAllDDDisabled() {
 	}
 	AssertEnvVars(s.T(), agentContainer, expectedAgentEnvVars)
 
+	// Verify process and container image collection are left to the agent defaults
+	AssertNotEnvVars(s.T(), agentContainer, []string{
+		"DD_PROCESS_CONFIG_PROCESS_COLLECTION_ENABLED",
+		"DD_CONTAINER_IMAGE_ENABLED",
+		"DD_SBOM_ENABLED",
+	})
+
 	// Verify agent health check
 	s.NotNil(agentContainer.HealthCheck, "Agent health check should be defined")
 	s.Contains(agentContainer.HealthCheck.Command, "/probe.sh", "Agent health check command should include probe.sh")
@@ -84,6 +91,11 @@ func (s *ECSFargateSuite) TestAllDDDisabled() {
 		"DD_TRACE_AGENT_URL",
 		"DD_DOGSTATSD_URL",
 		"DD_AGENT_HOST",
+		"OTEL_EXPORTER_OTLP_ENDPOINT",
+		"OTEL_RESOURCE_ATTRIBUTES",
+		"DD_APPSEC_ENABLED",
+		"DD_IAST_ENABLED",
+		"DD_APPSEC_SCA_ENABLED",
 	}
 	AssertNotEnvVars(s.T(), dummyContainer, unexpectedDummyEnvVars)
 
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"log"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 )
@@ -33,9 +34,13 @@ func (s *ECSFargateSuite) TestAllDDInputs() {
 	s.Equal("public.ecr.aws/datadog/agent:latest", *agentContainer.Image, "Unexpected image for datadog-agent")
 	s.True(*agentContainer.Essential, "datadog-agent should be essential")
 	s.Equal(types.LogDriverAwsfirelens, (*agentContainer.LogConfiguration).LogDriver, "Unexpected log driver for datadog-agent")
+	s.Equal(aws.Int32(256), agentContainer.Memory, "Unexpected memory limit for datadog-agent")
+	s.Nil(agentContainer.MemoryReservation, "Expected no memory reservation for datadog-agent")
 
 	AssertPortMapping(s.T(), agentContainer, PortUDP)
 	AssertPortMapping(s.T(), agentContainer, PortTCP)
+	AssertPortMapping(s.T(), agentContainer, PortOTLPGRPC)
+	AssertPortMapping(s.T(), agentContainer, PortOTLPHTTP)
 	AssertMountPoint(s.T(), agentContainer, MountDdSocket)
 	AssertContainerDependency(s.T(), agentContainer, DependencyLogRouter)
 
@@ -58,6 +63,24 @@ func (s *ECSFargateSuite) TestAllDDInputs() {
 	}
 	AssertEnvVars(s.T(), agentContainer, expectedAgentEnvvars)
 
+	expectedOtlpAgentEnvVars := map[string]string{
+		"DD_OTLP_CONFIG_RECEIVER_PROTOCOLS_GRPC_ENDPOINT": "0.0.0.0:4317",
+		"DD_OTLP_CONFIG_RECEIVER_PROTOCOLS_HTTP_ENDPOINT": "0.0.0.0:4318",
+	}
+	AssertEnvVars(s.T(), agentContainer, expectedOtlpAgentEnvVars)
+	AssertNotEnvVars(s.T(), agentContainer, []string{"DD_OTLP_CONFIG_LOGS_ENABLED"})
+	AssertEnvVars(s.T(), agentContainer, map[string]string{"DD_REMOTE_CONFIGURATION_ENABLED": "true"})
+
+	expectedCollectionEnvVars := map[string]string{
+		"DD_PROCESS_CONFIG_PROCESS_COLLECTION_ENABLED": "true",
+		"DD_PROCESS_CONFIG_SCRUB_ARGS":                 "true",
+		"DD_PROCESS_CONFIG_CUSTOM_SENSITIVE_WORDS":     "*token,sql*",
+		"DD_CONTAINER_IMAGE_ENABLED":                   "true",
+		"DD_SBOM_ENABLED":                              "true",
+		"DD_SBOM_CONTAINER_IMAGE_ENABLED":              "true",
+	}
+	AssertEnvVars(s.T(), agentContainer, expectedCollectionEnvVars)
+
 	expectedLogOptions := map[string]string{
 		"apikey":      "test-api-key",
 		"provider":    "ecs",
@@ -83,13 +106,17 @@ func (s *ECSFargateSuite) TestAllDDInputs() {
 	s.Equal("0", *logRouterContainer.User, "Unexpected user for datadog-log-router")
 	s.Equal(types.FirelensConfigurationTypeFluentbit, logRouterContainer.FirelensConfiguration.Type, "Unexpected firelens type")
 	s.Equal("true", logRouterContainer.FirelensConfiguration.Options["enable-ecs-log-metadata"], "Unexpected firelens option value")
+	s.Nil(logRouterContainer.Memory, "Expected no memory limit for datadog-log-router")
+	s.Equal(aws.Int32(64), logRouterContainer.MemoryReservation, "Unexpected memory reservation for datadog-log-router")
 
 	// Test CWS init container
 	cwsInitContainer, found := GetContainer(containers, "cws-instrumentation-init")
 	s.True(found, "Container cws-instrumentation-init not found in definitions")
-	s.Equal("datadog/cws-instrumentation:latest", *cwsInitContainer.Image)
+	s.Equal("public.ecr.aws/datadog/cws-instrumentation:latest", *cwsInitContainer.Image)
 	s.False(*cwsInitContainer.Essential, "cws-instrumentation-init should not be essential")
 	s.Equal("0", *cwsInitContainer.User, "Unexpected user for cws-instrumentation-init")
+	s.Equal(int32(100), cwsInitContainer.Cpu, "Unexpected CPU for cws-instrumentation-init")
+	s.Equal(aws.Int32(32), cwsInitContainer.Memory, "Unexpected memory limit for cws-instrumentation-init")
 	s.Equal([]string{"/cws-instrumentation", "setup", "--cws-volume-mount", "/cws-instrumentation-volume"}, cwsInitContainer.Command, "Unexpected command for cws-instrumentation-init")
 	AssertMountPoint(s.T(), cwsInitContainer, MountCWS)
 
@@ -124,13 +151,59 @@ func (s *ECSFargateSuite) TestAllDDInputs() {
 		"DD_TRACE_INFERRED_PROXY_SERVICES_ENABLED": "true",
 	}
 	AssertEnvVars(s.T(), apmAppContainer, expectedApmDsdEnvVars)
+
+	expectedOtlpAppEnvVars := map[string]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT": "http://127.0.0.1:4317",
+		"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+		"OTEL_RESOURCE_ATTRIBUTES":    "service.name=test-service",
+	}
+	AssertEnvVars(s.T(), apmAppContainer, expectedOtlpAppEnvVars)
+
+	expectedAppsecEnvVars := map[string]string{
+		"DD_APPSEC_ENABLED":               "true",
+		"DD_IAST_ENABLED":                 "true",
+		"DD_APPSEC_SCA_ENABLED":           "true",
+		"DD_REMOTE_CONFIGURATION_ENABLED": "true",
+	}
+	AssertEnvVars(s.T(), apmAppContainer, expectedAppsecEnvVars)
+	AssertNotEnvVars(s.T(), apmAppContainer, []string{"DD_APPSEC_RULES"})
+
+	expectedTracerEnvVars := map[string]string{
+		"DD_TRACE_SAMPLE_RATE":       "0.5",
+		"DD_TRACE_RATE_LIMIT":        "50",
+		"DD_DBM_PROPAGATION_MODE":    "full",
+		"DD_DATA_STREAMS_ENABLED":    "true",
+		"DD_LOGS_INJECTION":          "true",
+		"DD_RUNTIME_METRICS_ENABLED": "true",
+	}
+	AssertEnvVars(s.T(), apmAppContainer, expectedTracerEnvVars)
+
+	samplingRules, found := GetEnvVar(apmAppContainer, "DD_TRACE_SAMPLING_RULES")
+	s.True(found, "DD_TRACE_SAMPLING_RULES not found in datadog-apm-app container")
+	s.JSONEq(`[{"service":"test-service","sample_rate":1}]`, samplingRules, "Unexpected trace sampling rules")
 	AssertMountPoint(s.T(), apmAppContainer, MountDdSocket)
 	s.Nil(apmAppContainer.LinuxParameters, "LinuxParameters should be nil for datadog-apm-app")
 
+	// Verify Autodiscovery checks are rendered as docker labels
+	s.Equal("cont-p", apmAppContainer.DockerLabels["team"], "Existing docker labels should be preserved for datadog-apm-app")
+	var adChecks map[string]map[string]interface{}
+	err = json.Unmarshal([]byte(apmAppContainer.DockerLabels["com.datadoghq.ad.checks"]), &adChecks)
+	s.NoError(err, "Failed to parse Autodiscovery checks label")
+	s.Contains(adChecks, "http_check", "Autodiscovery checks should contain http_check")
+	s.Equal([]interface{}{map[string]interface{}{"name": "apm-app", "url": "http://%%host%%:8080"}}, adChecks["http_check"]["instances"],
+		"Unexpected http_check instances")
+	s.Contains(adChecks, "redisdb", "Autodiscovery checks should contain redisdb")
+	s.Equal(map[string]interface{}{}, adChecks["redisdb"]["init_config"], "Unexpected redisdb init_config")
+	s.Equal([]interface{}{
+		map[string]interface{}{"host": "%%host%%", "port": float64(6379), "tags": []interface{}{"role:cache", "tier:backend"}},
+		map[string]interface{}{"host": "%%host%%", "port": float64(6380), "password": "%%env_REDIS_PASSWORD%%"},
+	}, adChecks["redisdb"]["instances"], "Checks and instances with different shapes should keep their types")
+
 	// Test datadog-dogstatsd-app container
 	dogstatsdAppContainer, found := GetContainer(containers, "datadog-dogstatsd-app")
 	s.True(found, "Container datadog-dogstatsd-app not found in definitions")
 	s.Equal("ghcr.io/datadog/apps-dogstatsd:main", *dogstatsdAppContainer.Image)
 	AssertEnvVars(s.T(), dogstatsdAppContainer, expectedApmDsdEnvVars)
 	s.Nil(dogstatsdAppContainer.LinuxParameters, "LinuxParameters should be nil for datadog-dogstatsd-app")
+	s.NotContains(dogstatsdAppContainer.DockerLabels, "com.datadoghq.ad.checks", "Autodiscovery checks should not be set on datadog-dogstatsd-app")
 }
@@ -63,6 +63,9 @@ func (s *ECSFargateSuite) TestAllWindows() {
 	}
 	AssertEnvVars(s.T(), agentContainer, expectedAgentEnvVars)
 
+	// Verify the agent logs are sent to CloudWatch (Windows doesn't support Firelens)
+	s.Equal(types.LogDriverAwslogs, agentContainer.LogConfiguration.LogDriver, "Unexpected log driver for datadog-agent")
+
 	// Verify no mount points (Windows doesn't support sockets)
 	s.Equal(0, len(agentContainer.MountPoints), "Expected no mount points for datadog-agent in Windows")
 
@@ -103,6 +106,18 @@ func (s *ECSFargateSuite) TestAllWindows() {
 	// Verify APM app doesn't have socket-related env vars
 	AssertNotEnvVars(s.T(), apmContainer, apmDsdDisabledEnvVars)
 
+	// Verify application logs are sent to the forwarded CloudWatch log group
+	expectedLogOptions := map[string]string{
+		"awslogs-group":         "/ecs/" + s.testPrefix + "-all-windows",
+		"awslogs-region":        "us-east-1",
+		"awslogs-stream-prefix": "ecs",
+	}
+	for _, container := range []types.ContainerDefinition{dogstatsdContainer, apmContainer} {
+		s.Equal(types.LogDriverAwslogs, container.LogConfiguration.LogDriver, "Unexpected log driver for %s", *container.Name)
+		s.Equal(expectedLogOptions, container.LogConfiguration.Options, "Unexpected log options for %s", *container.Name)
+	}
+	s.Equal("/ecs/"+s.testPrefix+"-all-windows", task["dd_log_group_name"], "Unexpected log group name")
+
 	// Verify no mount points for application containers
 	s.Equal(0, len(dogstatsdContainer.MountPoints), "Expected no mount points for dogstatsd-app in Windows")
 	s.Equal(0, len(apmContainer.MountPoints), "Expected no mount points for apm-app in Windows")
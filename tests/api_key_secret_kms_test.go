@@ -0,0 +1,55 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// TestApiKeySecretKms tests the task definition with an API key secret encrypted with a customer managed KMS key
+func (s *ECSFargateSuite) TestApiKeySecretKms() {
+	log.Println("TestApiKeySecretKms: Running test...")
+
+	// Retrieve the task output for the "api-key-secret-kms" module
+	var containers []types.ContainerDefinition
+	task := terraform.OutputMap(s.T(), s.terraformOptions, "api-key-secret-kms")
+	s.Equal(s.testPrefix+"-api-key-secret-kms", task["family"], "Unexpected task family name")
+
+	err := json.Unmarshal([]byte(task["container_definitions"]), &containers)
+	s.NoError(err, "Failed to parse container definitions")
+
+	// Test Agent Container
+	agentContainer, found := GetContainer(containers, "datadog-agent")
+	s.True(found, "Container datadog-agent not found in definitions")
+	AssertNotEnvVars(s.T(), agentContainer, []string{"DD_API_KEY"})
+	s.Equal(1, len(agentContainer.Secrets), "Expected a single secret in datadog-agent")
+	secretArn := *agentContainer.Secrets[0].ValueFrom
+	s.Contains(secretArn, ":secret:"+s.testPrefix+"-dd-api-key", "DD_API_KEY should be sourced from the secret")
+
+	// Test the rendered execution role policy
+	var policy PolicyDocument
+	err = json.Unmarshal([]byte(task["dd_secret_access_policy"]), &policy)
+	s.NoError(err, "Failed to parse the Datadog secret access policy")
+	s.Equal(2, len(policy.Statement), "Expected 2 statements in the Datadog secret access policy")
+
+	secretStatement, found := GetPolicyStatement(policy, "secretsmanager:GetSecretValue")
+	s.True(found, "secretsmanager:GetSecretValue statement not found in policy")
+	s.Equal("Allow", secretStatement.Effect, "Unexpected effect for the secret statement")
+	s.Equal(StringOrSlice{secretArn}, secretStatement.Resource, "Secret access should be scoped to the API key secret")
+
+	kmsStatement, found := GetPolicyStatement(policy, "kms:Decrypt")
+	s.True(found, "kms:Decrypt statement not found in policy")
+	s.Equal("Allow", kmsStatement.Effect, "Unexpected effect for the KMS statement")
+	s.Equal(1, len(kmsStatement.Resource), "KMS access should be scoped to a single key")
+	s.Contains(kmsStatement.Resource[0], ":key/", "KMS access should be scoped to the customer managed key")
+	region := terraform.Output(s.T(), s.terraformOptions, "region")
+	s.Equal(StringOrSlice{"secretsmanager." + region + ".amazonaws.com"}, kmsStatement.Condition["StringEquals"]["kms:ViaService"],
+		"KMS access should only be allowed through Secrets Manager")
+}
@@ -0,0 +1,68 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// TestApiKeySsm tests the task definition with the Datadog API key sourced from SSM Parameter Store
+func (s *ECSFargateSuite) TestApiKeySsm() {
+	log.Println("TestApiKeySsm: Running test...")
+
+	// Retrieve the task output for the "api-key-ssm" module
+	var containers []types.ContainerDefinition
+	task := terraform.OutputMap(s.T(), s.terraformOptions, "api-key-ssm")
+	s.Equal(s.testPrefix+"-api-key-ssm", task["family"], "Unexpected task family name")
+	s.Contains(task["execution_role_arn"], s.testPrefix+"-api-key-ssm-ecs-task-exec-role", "Expected the module to create a task execution role")
+
+	err := json.Unmarshal([]byte(task["container_definitions"]), &containers)
+	s.NoError(err, "Failed to parse container definitions")
+	s.Equal(3, len(containers), "Expected 3 containers in the task definition")
+
+	parameterSuffix := ":parameter/" + s.testPrefix + "/dd-api-key"
+
+	// Test Agent Container
+	agentContainer, found := GetContainer(containers, "datadog-agent")
+	s.True(found, "Container datadog-agent not found in definitions")
+	AssertNotEnvVars(s.T(), agentContainer, []string{"DD_API_KEY"})
+	s.Equal(2, len(agentContainer.Secrets), "Expected 2 secrets in datadog-agent")
+	s.Equal("DD_API_KEY", *agentContainer.Secrets[0].Name, "Unexpected secret name in datadog-agent")
+	s.Contains(*agentContainer.Secrets[0].ValueFrom, parameterSuffix, "DD_API_KEY should be sourced from the SSM parameter")
+
+	// Verify additional secrets take precedence over dd_environment
+	s.Equal("DD_APP_KEY", *agentContainer.Secrets[1].Name, "Unexpected secret name in datadog-agent")
+	s.Contains(*agentContainer.Secrets[1].ValueFrom, ":parameter/"+s.testPrefix+"/dd-app-key", "DD_APP_KEY should be sourced from the SSM parameter")
+	AssertNotEnvVars(s.T(), agentContainer, []string{"DD_APP_KEY"})
+
+	// Verify the execution role policy is scoped to the parameters
+	var policy PolicyDocument
+	err = json.Unmarshal([]byte(task["dd_secret_access_policy"]), &policy)
+	s.NoError(err, "Failed to parse the Datadog secret access policy")
+	s.Equal(1, len(policy.Statement), "Expected a single statement in the Datadog secret access policy")
+	ssmStatement, found := GetPolicyStatement(policy, "ssm:GetParameters")
+	s.True(found, "ssm:GetParameters statement not found in policy")
+	s.ElementsMatch(StringOrSlice{*agentContainer.Secrets[0].ValueFrom, *agentContainer.Secrets[1].ValueFrom}, ssmStatement.Resource,
+		"SSM access should be scoped to the Datadog parameters")
+
+	// Verify the log configuration sources the API key from SSM
+	s.NotNil(agentContainer.LogConfiguration, "Agent log configuration should be defined")
+	_, exists := agentContainer.LogConfiguration.Options["apikey"]
+	s.False(exists, "Log option apikey should not be set in plaintext")
+	s.Equal(1, len(agentContainer.LogConfiguration.SecretOptions), "Expected a single log secret option")
+	s.Equal("apikey", *agentContainer.LogConfiguration.SecretOptions[0].Name, "Unexpected log secret option name")
+	s.Contains(*agentContainer.LogConfiguration.SecretOptions[0].ValueFrom, parameterSuffix, "apikey should be sourced from the SSM parameter")
+
+	// Test application container log configuration
+	dummyContainer, found := GetContainer(containers, "dummy-container")
+	s.True(found, "Container dummy-container not found in definitions")
+	s.Equal(types.LogDriverAwsfirelens, dummyContainer.LogConfiguration.LogDriver, "Unexpected log driver for dummy-container")
+	s.Contains(*dummyContainer.LogConfiguration.SecretOptions[0].ValueFrom, parameterSuffix, "apikey should be sourced from the SSM parameter")
+}
@@ -0,0 +1,75 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// TestAutoSizing tests that the Datadog sidecars are sized from the task size and the enabled features
+func (s *ECSFargateSuite) TestAutoSizing() {
+	log.Println("TestAutoSizing: Running test...")
+
+	// Retrieve the task output for the "auto-sizing" module
+	var containers []types.ContainerDefinition
+	task := terraform.OutputMap(s.T(), s.terraformOptions, "auto-sizing")
+	s.Equal(s.testPrefix+"-auto-sizing", task["family"], "Unexpected task family name")
+
+	err := json.Unmarshal([]byte(task["container_definitions"]), &containers)
+	s.NoError(err, "Failed to parse container definitions")
+	s.Equal(3, len(containers), "Expected 3 containers in the task definition")
+
+	// Test Agent Container: medium task base (128 CPU, 512 MiB) and APM (32 CPU, 64 MiB)
+	agentContainer, found := GetContainer(containers, "datadog-agent")
+	s.True(found, "Container datadog-agent not found in definitions")
+	s.Equal(int32(160), agentContainer.Cpu, "Unexpected datadog-agent CPU")
+	s.Nil(agentContainer.Memory, "Expected no hard memory limit for datadog-agent")
+	s.Equal(aws.Int32(576), agentContainer.MemoryReservation, "Unexpected datadog-agent memory reservation")
+
+	// Test Log Router Container: the explicit CPU takes precedence over the medium task base
+	logRouterContainer, found := GetContainer(containers, "datadog-log-router")
+	s.True(found, "Container datadog-log-router not found in definitions")
+	s.Equal(int32(96), logRouterContainer.Cpu, "Explicit log router CPU should take precedence")
+	s.Equal(aws.Int32(128), logRouterContainer.MemoryReservation, "Unexpected datadog-log-router memory reservation")
+
+	// Test Application Container is left unsized
+	appContainer, found := GetContainer(containers, "datadog-apm-app")
+	s.True(found, "Container datadog-apm-app not found in definitions")
+	s.Equal(int32(0), appContainer.Cpu, "Application container CPU should not be set")
+	s.Nil(appContainer.MemoryReservation, "Application container memory reservation should not be set")
+}
+
+// TestAutoSizingSmall tests that the computed sidecar sizes are scaled down to fit the smallest Fargate task size
+func (s *ECSFargateSuite) TestAutoSizingSmall() {
+	log.Println("TestAutoSizingSmall: Running test...")
+
+	// Retrieve the task output for the "auto-sizing-small" module
+	var containers []types.ContainerDefinition
+	task := terraform.OutputMap(s.T(), s.terraformOptions, "auto-sizing-small")
+	s.Equal(s.testPrefix+"-auto-sizing-small", task["family"], "Unexpected task family name")
+
+	err := json.Unmarshal([]byte(task["container_definitions"]), &containers)
+	s.NoError(err, "Failed to parse container definitions")
+	s.Equal(3, len(containers), "Expected 3 containers in the task definition")
+
+	// Test Agent Container: small task base (64 CPU, 256 MiB) and APM (32 CPU, 64 MiB), with
+	// the memory scaled down to leave half of the 512 MiB task to the application containers
+	agentContainer, found := GetContainer(containers, "datadog-agent")
+	s.True(found, "Container datadog-agent not found in definitions")
+	s.Equal(int32(96), agentContainer.Cpu, "Unexpected datadog-agent CPU")
+	s.Equal(aws.Int32(213), agentContainer.MemoryReservation, "Unexpected datadog-agent memory reservation")
+
+	// Test Log Router Container: small task base (32 CPU, 64 MiB) with the same memory scaling
+	logRouterContainer, found := GetContainer(containers, "datadog-log-router")
+	s.True(found, "Container datadog-log-router not found in definitions")
+	s.Equal(int32(32), logRouterContainer.Cpu, "Unexpected datadog-log-router CPU")
+	s.Equal(aws.Int32(42), logRouterContainer.MemoryReservation, "Unexpected datadog-log-router memory reservation")
+}
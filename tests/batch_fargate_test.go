@@ -0,0 +1,136 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// batchTaskProperties is the subset of the Batch ECS properties checked by the tests.
+// Batch task containers share their field names with ECS container definitions.
+type batchTaskProperties struct {
+	Containers       []types.ContainerDefinition `json:"containers"`
+	ExecutionRoleArn string                      `json:"executionRoleArn"`
+	TaskRoleArn      string                      `json:"taskRoleArn"`
+	PlatformVersion  string                      `json:"platformVersion"`
+	Volumes          []struct {
+		Name string `json:"name"`
+	} `json:"volumes"`
+}
+
+type batchEcsProperties struct {
+	TaskProperties []batchTaskProperties `json:"taskProperties"`
+}
+
+// TestBatchFargate tests the planned Batch job definitions, which are not applied
+func TestBatchFargate(t *testing.T) {
+	log.Println("TestBatchFargate: Running test...")
+
+	plan, testPrefix := planSmokeTest(t, "../smoke_tests/batch_fargate")
+
+	// Test ECS Properties Job Definition
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.dd_batch_job.aws_batch_job_definition.this")
+	job := plan.ResourcePlannedValuesMap["module.dd_batch_job.aws_batch_job_definition.this"].AttributeValues
+	assert.Equal(t, testPrefix+"-batch-job", job["name"], "Unexpected job definition name")
+	assert.ElementsMatch(t, []interface{}{"FARGATE"}, job["platform_capabilities"], "Unexpected platform capabilities")
+	assert.Equal(t, true, job["propagate_tags"], "Tags should be propagated to the ECS tasks")
+
+	var properties batchEcsProperties
+	err := json.Unmarshal([]byte(job["ecs_properties"].(string)), &properties)
+	assert.NoError(t, err, "Failed to parse job ECS properties")
+	assert.Len(t, properties.TaskProperties, 1, "Unexpected number of task properties")
+	task := properties.TaskProperties[0]
+	containers := task.Containers
+
+	assert.Len(t, task.Volumes, 1, "Unexpected number of volumes")
+	assert.Equal(t, "dd-sockets", task.Volumes[0].Name, "Unexpected socket volume")
+
+	// Test Agent Container
+	agentContainer, found := GetContainer(containers, "datadog-agent")
+	assert.True(t, found, "Container datadog-agent not found in definitions")
+	assert.False(t, *agentContainer.Essential, "The Datadog Agent must not be essential so the job can complete")
+	assert.Nil(t, agentContainer.HealthCheck, "Health checks are not supported by Batch")
+	assert.Empty(t, agentContainer.PortMappings, "Port mappings are not supported by Batch")
+	assert.ElementsMatch(t, []types.ResourceRequirement{
+		{Type: types.ResourceType("VCPU"), Value: aws.String("0.25")},
+		{Type: types.ResourceType("MEMORY"), Value: aws.String("512")},
+	}, agentContainer.ResourceRequirements, "Unexpected Datadog Agent resource requirements")
+	AssertEnvVars(t, agentContainer, map[string]string{
+		"DD_API_KEY":  "test-api-key",
+		"DD_SITE":     "datadoghq.com",
+		"ECS_FARGATE": "true",
+		"DD_ENV":      "prod",
+		"DD_SERVICE":  "test-service",
+		"DD_VERSION":  "1.0.0",
+	})
+	AssertMountPoint(t, agentContainer, MountDdSocket)
+
+	// Test Log Router Container
+	logRouterContainer, found := GetContainer(containers, "datadog-log-router")
+	assert.True(t, found, "Container datadog-log-router not found in definitions")
+	assert.False(t, *logRouterContainer.Essential, "The log router must not be essential so the job can complete")
+	assert.Equal(t, types.FirelensConfigurationTypeFluentbit, logRouterContainer.FirelensConfiguration.Type, "Unexpected firelens configuration type")
+
+	// Test Application Container
+	appContainer, found := GetContainer(containers, "datadog-batch-app")
+	assert.True(t, found, "Container datadog-batch-app not found in definitions")
+	assert.Equal(t, []string{"python", "job.py"}, appContainer.Command, "Unexpected application command")
+	assert.Equal(t, types.LogDriverAwsfirelens, appContainer.LogConfiguration.LogDriver, "Application logs should be routed to firelens")
+	AssertEnvVars(t, appContainer, map[string]string{
+		"DD_TRACE_AGENT_URL": "unix:///var/run/datadog/apm.socket",
+		"DD_DOGSTATSD_URL":   "unix:///var/run/datadog/dsd.socket",
+		"DD_ENV":             "prod",
+		"DD_SERVICE":         "test-service",
+		"DD_VERSION":         "1.0.0",
+	})
+	AssertMountPoint(t, appContainer, MountDdSocket)
+	AssertContainerDependency(t, appContainer, types.ContainerDependency{
+		ContainerName: aws.String("datadog-log-router"),
+		Condition:     types.ContainerConditionStart,
+	})
+	for _, dependency := range appContainer.DependsOn {
+		assert.NotEqual(t, types.ContainerConditionHealthy, dependency.Condition, "HEALTHY dependencies are not supported by Batch")
+	}
+
+	// Test Container Properties Job Definition
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.dd_batch_job_container_properties.aws_batch_job_definition.this")
+	containerPropertiesJob := plan.ResourcePlannedValuesMap["module.dd_batch_job_container_properties.aws_batch_job_definition.this"].AttributeValues
+	AssertResourceTags(t, map[string]interface{}{
+		"team": "cont-p",
+	}, containerPropertiesJob["tags"], "Unexpected job definition tags")
+
+	var containerProperties batchEcsProperties
+	err = json.Unmarshal([]byte(containerPropertiesJob["ecs_properties"].(string)), &containerProperties)
+	assert.NoError(t, err, "Failed to parse job ECS properties")
+	containerPropertiesTask := containerProperties.TaskProperties[0]
+	assert.Equal(t, "arn:aws:iam::123456789012:role/batch-job-role", containerPropertiesTask.TaskRoleArn, "The job role should be used as the task role")
+	assert.Equal(t, "LATEST", containerPropertiesTask.PlatformVersion, "Unexpected platform version")
+
+	mainContainer, found := GetContainer(containerPropertiesTask.Containers, testPrefix+"-batch-job-container-properties")
+	assert.True(t, found, "The job container should be named after the job definition")
+	assert.True(t, *mainContainer.Essential, "The job container must be essential")
+	AssertEnvVars(t, mainContainer, map[string]string{
+		"APP_MODE":         "batch",
+		"DD_DOGSTATSD_URL": "unix:///var/run/datadog/dsd.socket",
+		"DD_SERVICE":       "test-service",
+	})
+	AssertNotEnvVars(t, mainContainer, []string{"DD_TRACE_AGENT_URL"})
+
+	containerPropertiesAgent, found := GetContainer(containerPropertiesTask.Containers, "datadog-agent")
+	assert.True(t, found, "Container datadog-agent not found in definitions")
+	assert.False(t, *containerPropertiesAgent.Essential, "The Datadog Agent must not be essential so the job can complete")
+	assert.Equal(t, "DD_API_KEY", *containerPropertiesAgent.Secrets[0].Name, "The API key should be provided as a secret")
+
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.dd_batch_job_container_properties.module.dd_task_roles.aws_iam_role.new_ecs_task_execution_role[0]")
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.dd_batch_job_container_properties.module.dd_task_roles.aws_iam_role_policy_attachment.new_role_dd_secret[0]")
+}
@@ -0,0 +1,47 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// TestContainerDefinitions tests that JSON string and list of objects container definitions yield the same task definition
+func (s *ECSFargateSuite) TestContainerDefinitions() {
+	log.Println("TestContainerDefinitions: Running test...")
+
+	// Retrieve the task outputs for the "container-definitions-json" and "container-definitions-list" modules
+	var jsonContainers []types.ContainerDefinition
+	jsonTask := terraform.OutputMap(s.T(), s.terraformOptions, "container-definitions-json")
+	s.Equal(s.testPrefix+"-container-definitions-json", jsonTask["family"], "Unexpected task family name")
+
+	err := json.Unmarshal([]byte(jsonTask["container_definitions"]), &jsonContainers)
+	s.NoError(err, "Failed to parse JSON string container definitions")
+
+	var listContainers []types.ContainerDefinition
+	listTask := terraform.OutputMap(s.T(), s.terraformOptions, "container-definitions-list")
+	s.Equal(s.testPrefix+"-container-definitions-list", listTask["family"], "Unexpected task family name")
+
+	err = json.Unmarshal([]byte(listTask["container_definitions"]), &listContainers)
+	s.NoError(err, "Failed to parse list of objects container definitions")
+
+	// Test Containers
+	s.Equal(jsonContainers, listContainers, "Container definitions differ between the JSON string and list of objects inputs")
+
+	// Test Application Container
+	dogstatsdContainer, found := GetContainer(listContainers, "datadog-dogstatsd-app")
+	s.True(found, "Container datadog-dogstatsd-app not found in definitions")
+	AssertEnvVars(s.T(), dogstatsdContainer, map[string]string{
+		"APP_MODE":         "smoke",
+		"DD_DOGSTATSD_URL": "unix:///var/run/datadog/dsd.socket",
+		"DD_SERVICE":       "test-service",
+	})
+	AssertMountPoint(s.T(), dogstatsdContainer, MountDdSocket)
+}
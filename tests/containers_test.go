@@ -0,0 +1,90 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// TestContainers tests that the render-only submodule produces the containers rendered by the
+// ecs_fargate module before they were extracted to it, stored in `testdata/containers.json`
+func (s *ECSFargateSuite) TestContainers() {
+	log.Println("TestContainers: Running test...")
+
+	// Retrieve the expected containers rendered by the ecs_fargate module from the same inputs
+	var expectedContainers []types.ContainerDefinition
+	golden, err := os.ReadFile("testdata/containers.json")
+	s.NoError(err, "Failed to read expected container definitions")
+
+	err = json.Unmarshal(golden, &expectedContainers)
+	s.NoError(err, "Failed to parse expected container definitions")
+
+	// Retrieve the rendered output for the "containers-only" module
+	var renderedContainers []types.ContainerDefinition
+	rendered := terraform.OutputMapOfObjects(s.T(), s.terraformOptions, "containers-only")
+
+	err = json.Unmarshal([]byte(rendered["container_definitions"].(string)), &renderedContainers)
+	s.NoError(err, "Failed to parse rendered container definitions")
+
+	// The module version is reported by the agent and in the tags, and changes with each release
+	tags := rendered["tags"].(map[string]interface{})
+	s.Len(tags, 1, "Unexpected rendered tags")
+	agentContainer, found := GetContainer(renderedContainers, "datadog-agent")
+	s.True(found, "Container datadog-agent not found in rendered definitions")
+	AssertEnvVars(s.T(), agentContainer, map[string]string{
+		"DD_INSTALL_INFO_INSTALLER_VERSION": tags["dd_ecs_terraform_module"].(string),
+	})
+
+	// Test Containers
+	s.Equal(len(expectedContainers), len(renderedContainers), "Unexpected number of rendered containers")
+	for _, name := range []string{"datadog-agent", "datadog-log-router", "cws-instrumentation-init", "datadog-apm-app"} {
+		expectedContainer, found := GetContainer(expectedContainers, name)
+		s.True(found, "Container %s not found in expected definitions", name)
+		renderedContainer, found := GetContainer(renderedContainers, name)
+		s.True(found, "Container %s not found in rendered definitions", name)
+
+		s.Equal(expectedContainer.Image, renderedContainer.Image, "Unexpected image for %s", name)
+		s.Equal(expectedContainer.Essential, renderedContainer.Essential, "Unexpected essential flag for %s", name)
+		s.Equal(expectedContainer.EntryPoint, renderedContainer.EntryPoint, "Unexpected entry point for %s", name)
+		s.Equal(expectedContainer.DockerLabels, renderedContainer.DockerLabels, "Unexpected docker labels for %s", name)
+		s.Equal(expectedContainer.LogConfiguration, renderedContainer.LogConfiguration, "Unexpected log configuration for %s", name)
+		s.ElementsMatch(withoutInstallerVersion(expectedContainer.Environment), withoutInstallerVersion(renderedContainer.Environment), "Unexpected environment for %s", name)
+		s.ElementsMatch(expectedContainer.Secrets, renderedContainer.Secrets, "Unexpected secrets for %s", name)
+		s.ElementsMatch(expectedContainer.MountPoints, renderedContainer.MountPoints, "Unexpected mount points for %s", name)
+		s.ElementsMatch(expectedContainer.DependsOn, renderedContainer.DependsOn, "Unexpected dependencies for %s", name)
+	}
+
+	// Test Volumes
+	volumes := []string{}
+	for _, volume := range rendered["volumes"].([]interface{}) {
+		volumes = append(volumes, volume.(map[string]interface{})["name"].(string))
+	}
+	s.ElementsMatch([]string{"app-storage", "dd-sockets", "cws-instrumentation-volume"}, volumes, "Unexpected rendered volumes")
+
+	// Test IAM Policy Statements
+	s.Equal(false, rendered["is_execution_role_policy_required"], "No execution role permissions expected with a plaintext API key")
+	s.Empty(rendered["execution_role_policy_statements"], "No execution role statements expected with a plaintext API key")
+	statements := rendered["task_role_policy_statements"].([]interface{})
+	s.Len(statements, 1, "Unexpected number of task role statements")
+	s.Contains(statements[0].(map[string]interface{})["actions"], "ecs:ListClusters", "Expected ecs:ListClusters on the task role")
+}
+
+// withoutInstallerVersion removes the module version, which is checked against the tags instead
+func withoutInstallerVersion(environment []types.KeyValuePair) []types.KeyValuePair {
+	filtered := []types.KeyValuePair{}
+	for _, env := range environment {
+		if env.Name != nil && *env.Name == "DD_INSTALL_INFO_INSTALLER_VERSION" {
+			continue
+		}
+		filtered = append(filtered, env)
+	}
+	return filtered
+}
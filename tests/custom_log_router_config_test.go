@@ -0,0 +1,86 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// TestCustomLogRouterConfig tests the custom fluent-bit configuration of the log router
+func (s *ECSFargateSuite) TestCustomLogRouterConfig() {
+	log.Println("TestCustomLogRouterConfig: Running test...")
+
+	// Retrieve the task output for the "custom-log-router-config" module
+	var containers []types.ContainerDefinition
+	task := terraform.OutputMap(s.T(), s.terraformOptions, "custom-log-router-config")
+	s.Equal(s.testPrefix+"-custom-log-router-config", task["family"], "Unexpected task family name")
+
+	err := json.Unmarshal([]byte(task["container_definitions"]), &containers)
+	s.NoError(err, "Failed to parse container definitions")
+
+	// Test Log Router Container with inline parsers and filters
+	logRouterContainer, found := GetContainer(containers, "datadog-log-router")
+	s.True(found, "Container datadog-log-router not found in definitions")
+	s.Equal("file", logRouterContainer.FirelensConfiguration.Options["config-file-type"], "Unexpected firelens config file type")
+	s.Equal("/fluent-bit/etc/datadog/dd-custom.conf", logRouterContainer.FirelensConfiguration.Options["config-file-value"], "Unexpected firelens config file")
+	s.Empty(logRouterContainer.EntryPoint, "The log router entrypoint should not be overridden")
+	AssertMountPoint(s.T(), logRouterContainer, types.MountPoint{SourceVolume: aws.String("dd-log-router-config"), ContainerPath: aws.String("/fluent-bit/etc/datadog"), ReadOnly: aws.Bool(true)})
+
+	// Test Init Container writes the custom configuration files
+	initContainer, found := GetContainer(containers, "datadog-init")
+	s.True(found, "Container datadog-init not found in definitions")
+	s.Equal(1, len(initContainer.Command), "Expected a single command for datadog-init")
+	s.Contains(initContainer.Command[0], "> /fluent-bit/etc/datadog/dd-custom-parsers.conf", "datadog-init should write the custom parsers")
+	s.Contains(initContainer.Command[0], "> /fluent-bit/etc/datadog/dd-custom.conf", "datadog-init should write the custom configuration")
+
+	customConfig, found := GetEnvVar(initContainer, "DD_LOG_ROUTER_CUSTOM_CONFIG")
+	s.True(found, "DD_LOG_ROUTER_CUSTOM_CONFIG not found in datadog-init container")
+	s.Contains(customConfig, "Parsers_File /fluent-bit/etc/datadog/dd-custom-parsers.conf", "The custom configuration should load the custom parsers")
+	s.Contains(customConfig, "Parser   app_json", "The custom configuration should contain the custom filters")
+
+	customParsers, found := GetEnvVar(initContainer, "DD_LOG_ROUTER_CUSTOM_PARSERS")
+	s.True(found, "DD_LOG_ROUTER_CUSTOM_PARSERS not found in datadog-init container")
+	s.Contains(customParsers, "Name   app_json", "Unexpected custom parsers")
+
+	// Retrieve the task output for the "custom-log-router-config-s3" module
+	var s3Containers []types.ContainerDefinition
+	s3Task := terraform.OutputMap(s.T(), s.terraformOptions, "custom-log-router-config-s3")
+	s.Equal(s.testPrefix+"-custom-log-router-config-s3", s3Task["family"], "Unexpected task family name")
+
+	err = json.Unmarshal([]byte(s3Task["container_definitions"]), &s3Containers)
+	s.NoError(err, "Failed to parse container definitions")
+
+	// Test Log Router Container with an S3 configuration file
+	s3ConfigArn := "arn:aws:s3:::" + s.testPrefix + "-fluent-bit/config/extra.conf"
+	s3LogRouterContainer, found := GetContainer(s3Containers, "datadog-log-router")
+	s.True(found, "Container datadog-log-router not found in definitions")
+	s.Equal("public.ecr.aws/aws-observability/aws-for-fluent-bit:init-latest", *s3LogRouterContainer.Image, "S3 configuration files require the init image")
+	s.NotContains(s3LogRouterContainer.FirelensConfiguration.Options, "config-file-type", "S3 configuration files are not Firelens options on Fargate")
+	s.Empty(s3LogRouterContainer.EntryPoint, "The init image entrypoint should not be overridden")
+	_, found = GetContainer(s3Containers, "datadog-init")
+	s.False(found, "S3 configuration files do not require datadog-init")
+	AssertEnvVars(s.T(), s3LogRouterContainer, map[string]string{
+		"aws_fluent_bit_init_s3_1": s3ConfigArn,
+	})
+
+	// Test the rendered task role policy
+	var policy PolicyDocument
+	err = json.Unmarshal([]byte(s3Task["dd_task_permissions_policy"]), &policy)
+	s.NoError(err, "Failed to parse the Datadog task permissions policy")
+
+	getObjectStatement, found := GetPolicyStatement(policy, "s3:GetObject")
+	s.True(found, "s3:GetObject statement not found in policy")
+	s.Equal(StringOrSlice{s3ConfigArn}, getObjectStatement.Resource, "Unexpected s3:GetObject resources")
+
+	bucketStatement, found := GetPolicyStatement(policy, "s3:GetBucketLocation")
+	s.True(found, "s3:GetBucketLocation statement not found in policy")
+	s.Equal(StringOrSlice{"arn:aws:s3:::" + s.testPrefix + "-fluent-bit"}, bucketStatement.Resource, "Unexpected s3:GetBucketLocation resources")
+}
@@ -0,0 +1,130 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestECSEC2 tests the planned Datadog Agent daemon and instrumented application tasks, which are not applied
+func TestECSEC2(t *testing.T) {
+	log.Println("TestECSEC2: Running test...")
+
+	plan, testPrefix := planSmokeTest(t, "../smoke_tests/ecs_ec2")
+
+	// Test Daemon Service
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.dd_daemon.aws_ecs_service.this")
+	service := plan.ResourcePlannedValuesMap["module.dd_daemon.aws_ecs_service.this"].AttributeValues
+	assert.Equal(t, testPrefix+"-daemon", service["name"], "Service name should default to the task family")
+	assert.Equal(t, testPrefix+"-ec2-cluster", service["cluster"], "Unexpected service cluster")
+	assert.Equal(t, "DAEMON", service["scheduling_strategy"], "The Datadog Agent should run as a daemon")
+	assert.Equal(t, "EC2", service["launch_type"], "Unexpected service launch type")
+
+	// Test Daemon Task Definition: Bridge Network Mode with Sockets and Logs
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.dd_daemon.aws_ecs_task_definition.this")
+	task := plan.ResourcePlannedValuesMap["module.dd_daemon.aws_ecs_task_definition.this"].AttributeValues
+	assert.Equal(t, "bridge", task["network_mode"], "Unexpected daemon network mode")
+	assert.ElementsMatch(t, []interface{}{"EC2"}, task["requires_compatibilities"], "Unexpected daemon compatibilities")
+
+	volumes := map[string]interface{}{}
+	for _, volume := range task["volume"].([]interface{}) {
+		volumes[volume.(map[string]interface{})["name"].(string)] = volume.(map[string]interface{})["host_path"]
+	}
+	assert.Equal(t, map[string]interface{}{
+		"docker_sock":     "/var/run/docker.sock",
+		"proc":            "/proc/",
+		"cgroup":          "/sys/fs/cgroup/",
+		"dd-sockets":      "/var/run/datadog",
+		"pointdir":        "/opt/datadog-agent/run",
+		"containers_root": "/var/lib/docker/containers",
+	}, volumes, "Unexpected daemon host volumes")
+
+	var containers []types.ContainerDefinition
+	err := json.Unmarshal([]byte(task["container_definitions"].(string)), &containers)
+	assert.NoError(t, err, "Failed to parse daemon container definitions")
+
+	agentContainer, found := GetContainer(containers, "datadog-agent")
+	assert.True(t, found, "Container datadog-agent not found in definitions")
+	AssertEnvVars(t, agentContainer, map[string]string{
+		"DD_API_KEY":                           "test-api-key",
+		"DD_SITE":                              "datadoghq.com",
+		"DD_CLUSTER_NAME":                      testPrefix + "-ec2-cluster",
+		"DD_APM_ENABLED":                       "true",
+		"DD_APM_NON_LOCAL_TRAFFIC":             "true",
+		"DD_DOGSTATSD_NON_LOCAL_TRAFFIC":       "true",
+		"DD_LOGS_ENABLED":                      "true",
+		"DD_LOGS_CONFIG_CONTAINER_COLLECT_ALL": "true",
+	})
+	AssertMountPoint(t, agentContainer, MountDdSocket)
+	AssertPortMapping(t, agentContainer, PortUDP)
+	AssertPortMapping(t, agentContainer, PortTCP)
+
+	// Test Daemon Task Definition: Host Network Mode with Ports
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.dd_daemon_host.aws_ecs_service.this")
+	hostService := plan.ResourcePlannedValuesMap["module.dd_daemon_host.aws_ecs_service.this"].AttributeValues
+	assert.Equal(t, testPrefix+"-datadog-agent", hostService["name"], "Unexpected service name")
+
+	hostTask := plan.ResourcePlannedValuesMap["module.dd_daemon_host.aws_ecs_task_definition.this"].AttributeValues
+	assert.Equal(t, "host", hostTask["network_mode"], "Unexpected daemon network mode")
+
+	var hostContainers []types.ContainerDefinition
+	err = json.Unmarshal([]byte(hostTask["container_definitions"].(string)), &hostContainers)
+	assert.NoError(t, err, "Failed to parse daemon container definitions")
+
+	hostAgentContainer, found := GetContainer(hostContainers, "datadog-agent")
+	assert.True(t, found, "Container datadog-agent not found in definitions")
+	AssertNotEnvVars(t, hostAgentContainer, []string{"DD_API_KEY", "DD_LOGS_ENABLED"})
+	assert.Len(t, hostAgentContainer.MountPoints, 3, "Only the docker socket, proc and cgroup volumes should be mounted")
+
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.dd_daemon_host.aws_iam_role.new_ecs_task_execution_role[0]")
+
+	// Test Application Task: Sockets
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "aws_ecs_task_definition.app")
+	appTask := plan.ResourcePlannedValuesMap["aws_ecs_task_definition.app"].AttributeValues
+
+	var appContainers []types.ContainerDefinition
+	err = json.Unmarshal([]byte(appTask["container_definitions"].(string)), &appContainers)
+	assert.NoError(t, err, "Failed to parse application container definitions")
+
+	apmContainer, found := GetContainer(appContainers, "datadog-apm-app")
+	assert.True(t, found, "Container datadog-apm-app not found in definitions")
+	AssertEnvVars(t, apmContainer, map[string]string{
+		"DD_TRACE_AGENT_URL": "unix:///var/run/datadog/apm.socket",
+		"DD_DOGSTATSD_URL":   "unix:///var/run/datadog/dsd.socket",
+		"DD_ENV":             "prod",
+		"DD_SERVICE":         "test-service",
+		"DD_VERSION":         "1.0.0",
+	})
+	AssertNotEnvVars(t, apmContainer, []string{"DD_AGENT_HOST"})
+	AssertMountPoint(t, apmContainer, MountDdSocket)
+
+	appVolume := appTask["volume"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "dd-sockets", appVolume["name"], "Unexpected application volume")
+	assert.Equal(t, "/var/run/datadog", appVolume["host_path"], "The sockets should be shared through the host")
+
+	// Test Application Task: Host Network Mode with Ports
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "aws_ecs_task_definition.app_host")
+	appHostTask := plan.ResourcePlannedValuesMap["aws_ecs_task_definition.app_host"].AttributeValues
+
+	var appHostContainers []types.ContainerDefinition
+	err = json.Unmarshal([]byte(appHostTask["container_definitions"].(string)), &appHostContainers)
+	assert.NoError(t, err, "Failed to parse application container definitions")
+
+	dogstatsdContainer, found := GetContainer(appHostContainers, "datadog-dogstatsd-app")
+	assert.True(t, found, "Container datadog-dogstatsd-app not found in definitions")
+	AssertEnvVars(t, dogstatsdContainer, map[string]string{
+		"DD_AGENT_HOST": "127.0.0.1",
+		"DD_SERVICE":    "test-service",
+	})
+	AssertNotEnvVars(t, dogstatsdContainer, []string{"DD_TRACE_AGENT_URL", "DD_DOGSTATSD_URL"})
+	assert.Empty(t, dogstatsdContainer.MountPoints, "No sockets should be mounted")
+}
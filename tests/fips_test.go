@@ -0,0 +1,50 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// TestFips tests the task definition with the FIPS Agent enabled
+func (s *ECSFargateSuite) TestFips() {
+	log.Println("TestFips: Running test...")
+
+	// Retrieve the task output for the "fips" module
+	var containers []types.ContainerDefinition
+	task := terraform.OutputMap(s.T(), s.terraformOptions, "fips")
+	s.Equal(s.testPrefix+"-fips", task["family"], "Unexpected task family name")
+
+	err := json.Unmarshal([]byte(task["container_definitions"]), &containers)
+	s.NoError(err, "Failed to parse container definitions")
+
+	// Test Agent Container
+	agentContainer, found := GetContainer(containers, "datadog-agent")
+	s.True(found, "Container datadog-agent not found in definitions")
+	s.Equal(ImageReference{Repository: "public.ecr.aws/datadog/agent", Tag: "7.65.0-fips"}, ParseImageReference(*agentContainer.Image),
+		"The FIPS Agent image variant should be used")
+	AssertEnvVars(s.T(), agentContainer, map[string]string{
+		"DD_SITE": "ddog-gov.com",
+	})
+	// The FIPS Agent natively uses FIPS-validated cryptography, without the FIPS proxy settings
+	AssertNotEnvVars(s.T(), agentContainer, []string{"DD_FIPS_ENABLED", "DD_FIPS_HTTPS", "DD_FIPS_PORT_RANGE_START", "DD_FIPS_LOCAL_ADDRESS"})
+
+	// Test Firelens log configuration
+	s.NotNil(agentContainer.LogConfiguration, "Expected a log configuration for datadog-agent")
+	s.Equal(types.LogDriverAwsfirelens, agentContainer.LogConfiguration.LogDriver, "Unexpected log driver for datadog-agent")
+	s.Equal("http-intake.logs.ddog-gov.com", agentContainer.LogConfiguration.Options["Host"], "Logs should be sent to the FIPS-capable site")
+	s.Equal("on", agentContainer.LogConfiguration.Options["TLS"], "TLS should be forced on the log router output")
+
+	// Test Application Container
+	appContainer, found := GetContainer(containers, "dummy-container")
+	s.True(found, "Container dummy-container not found in definitions")
+	s.NotNil(appContainer.LogConfiguration, "Expected a log configuration for dummy-container")
+	s.Equal("on", appContainer.LogConfiguration.Options["TLS"], "TLS should be forced on the log router output")
+}
@@ -0,0 +1,51 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// TestImageDigests tests the task definition with all Datadog sidecar images pinned by digest
+func (s *ECSFargateSuite) TestImageDigests() {
+	log.Println("TestImageDigests: Running test...")
+
+	// Retrieve the task output for the "image-digests" module
+	var containers []types.ContainerDefinition
+	task := terraform.OutputMap(s.T(), s.terraformOptions, "image-digests")
+	s.Equal(s.testPrefix+"-image-digests", task["family"], "Unexpected task family name")
+
+	err := json.Unmarshal([]byte(task["container_definitions"]), &containers)
+	s.NoError(err, "Failed to parse container definitions")
+
+	// Test Agent Container
+	agentContainer, found := GetContainer(containers, "datadog-agent")
+	s.True(found, "Container datadog-agent not found in definitions")
+	AssertImageDigest(s.T(), agentContainer, "public.ecr.aws/datadog/agent",
+		"sha256:4ec2ad7b23e2e7b3b1c3a8f8e6c1b49c5a3a4f1b1d1d3e0e7a1c6b2b8f4a9d01")
+
+	// Test Log Router Container
+	logRouterContainer, found := GetContainer(containers, "datadog-log-router")
+	s.True(found, "Container datadog-log-router not found in definitions")
+	AssertImageDigest(s.T(), logRouterContainer, "public.ecr.aws/aws-observability/aws-for-fluent-bit",
+		"sha256:7b5b0c0f1f2a9e3d4c6b8a7f5e1d2c3b4a5968778695a4b3c2d1e0f9a8b7c602")
+
+	// Test CWS Container
+	cwsContainer, found := GetContainer(containers, "cws-instrumentation-init")
+	s.True(found, "Container cws-instrumentation-init not found in definitions")
+	AssertImageDigest(s.T(), cwsContainer, "public.ecr.aws/datadog/cws-instrumentation",
+		"sha256:a1b2c3d4e5f60718293a4b5c6d7e8f90112233445566778899aabbccddeeff03")
+
+	// Test Application Container
+	appContainer, found := GetContainer(containers, "dummy-container")
+	s.True(found, "Container dummy-container not found in definitions")
+	s.Equal(ImageReference{Repository: "ubuntu", Tag: "latest"}, ParseImageReference(*appContainer.Image),
+		"Application container images should not be modified")
+}
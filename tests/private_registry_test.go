@@ -0,0 +1,65 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// TestPrivateRegistry tests the task definition with Datadog sidecar images pulled from a private registry
+func (s *ECSFargateSuite) TestPrivateRegistry() {
+	log.Println("TestPrivateRegistry: Running test...")
+
+	// Retrieve the task output for the "private-registry" module
+	var containers []types.ContainerDefinition
+	task := terraform.OutputMap(s.T(), s.terraformOptions, "private-registry")
+	s.Equal(s.testPrefix+"-private-registry", task["family"], "Unexpected task family name")
+
+	err := json.Unmarshal([]byte(task["container_definitions"]), &containers)
+	s.NoError(err, "Failed to parse container definitions")
+
+	// Test Agent Container
+	agentContainer, found := GetContainer(containers, "datadog-agent")
+	s.True(found, "Container datadog-agent not found in definitions")
+	s.Equal("docker.io/datadog/agent:7.60.0", *agentContainer.Image, "Unexpected image for datadog-agent")
+	s.NotNil(agentContainer.RepositoryCredentials, "Expected repository credentials on datadog-agent")
+	registryCredentials := *agentContainer.RepositoryCredentials.CredentialsParameter
+	s.Contains(registryCredentials, ":secret:"+s.testPrefix+"-dd-registry-credentials", "Unexpected repository credentials for datadog-agent")
+
+	// Test Log Router Container
+	logRouterContainer, found := GetContainer(containers, "datadog-log-router")
+	s.True(found, "Container datadog-log-router not found in definitions")
+	s.NotNil(logRouterContainer.RepositoryCredentials, "Expected repository credentials on datadog-log-router")
+	logRouterCredentials := *logRouterContainer.RepositoryCredentials.CredentialsParameter
+	s.Contains(logRouterCredentials, ":secret:"+s.testPrefix+"-dd-log-router-registry-credentials", "Unexpected repository credentials for datadog-log-router")
+
+	// Test CWS Container
+	cwsContainer, found := GetContainer(containers, "cws-instrumentation-init")
+	s.True(found, "Container cws-instrumentation-init not found in definitions")
+	s.Equal("docker.io/datadog/cws-instrumentation:7.60.0", *cwsContainer.Image, "Unexpected image for cws-instrumentation-init")
+	s.NotNil(cwsContainer.RepositoryCredentials, "Expected repository credentials on cws-instrumentation-init")
+	s.Equal(registryCredentials, *cwsContainer.RepositoryCredentials.CredentialsParameter, "Unexpected repository credentials for cws-instrumentation-init")
+
+	// Test Application Container
+	appContainer, found := GetContainer(containers, "dummy-container")
+	s.True(found, "Container dummy-container not found in definitions")
+	s.Nil(appContainer.RepositoryCredentials, "Application containers should keep their own repository credentials")
+
+	// Test the rendered execution role policy
+	var policy PolicyDocument
+	err = json.Unmarshal([]byte(task["dd_secret_access_policy"]), &policy)
+	s.NoError(err, "Failed to parse the Datadog secret access policy")
+	s.Equal(1, len(policy.Statement), "Expected a single statement in the Datadog secret access policy")
+
+	secretStatement, found := GetPolicyStatement(policy, "secretsmanager:GetSecretValue")
+	s.True(found, "secretsmanager:GetSecretValue statement not found in policy")
+	s.ElementsMatch([]string{registryCredentials, logRouterCredentials}, []string(secretStatement.Resource),
+		"Secret access should be scoped to the registry credentials")
+}
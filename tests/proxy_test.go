@@ -0,0 +1,84 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// TestProxy tests the task definition with an outbound HTTP proxy
+func (s *ECSFargateSuite) TestProxy() {
+	log.Println("TestProxy: Running test...")
+
+	// Retrieve the task output for the "proxy" module
+	var containers []types.ContainerDefinition
+	task := terraform.OutputMap(s.T(), s.terraformOptions, "proxy")
+	s.Equal(s.testPrefix+"-proxy", task["family"], "Unexpected task family name")
+
+	err := json.Unmarshal([]byte(task["container_definitions"]), &containers)
+	s.NoError(err, "Failed to parse container definitions")
+
+	// Test Agent Container
+	agentContainer, found := GetContainer(containers, "datadog-agent")
+	s.True(found, "Container datadog-agent not found in definitions")
+	AssertEnvVars(s.T(), agentContainer, map[string]string{
+		"DD_PROXY_HTTPS":    "https://proxy.internal:3129",
+		"DD_PROXY_HTTP":     "http://proxy.internal:3128",
+		"DD_PROXY_NO_PROXY": "169.254.169.254 169.254.170.2",
+	})
+
+	// Test Firelens log configuration uses the http:// proxy
+	s.NotNil(agentContainer.LogConfiguration, "Expected a log configuration for datadog-agent")
+	s.Equal("http://proxy.internal:3128", agentContainer.LogConfiguration.Options["proxy"], "Unexpected proxy for the log router output")
+}
+
+// TestProxySecret tests the task definition with a proxy URL sourced from SSM Parameter Store
+func (s *ECSFargateSuite) TestProxySecret() {
+	log.Println("TestProxySecret: Running test...")
+
+	// Retrieve the task output for the "proxy-secret" module
+	var containers []types.ContainerDefinition
+	task := terraform.OutputMap(s.T(), s.terraformOptions, "proxy-secret")
+	s.Equal(s.testPrefix+"-proxy-secret", task["family"], "Unexpected task family name")
+
+	err := json.Unmarshal([]byte(task["container_definitions"]), &containers)
+	s.NoError(err, "Failed to parse container definitions")
+
+	// Test Agent Container
+	agentContainer, found := GetContainer(containers, "datadog-agent")
+	s.True(found, "Container datadog-agent not found in definitions")
+	AssertNotEnvVars(s.T(), agentContainer, []string{"DD_PROXY_HTTPS", "DD_PROXY_HTTP", "DD_PROXY_NO_PROXY"})
+
+	secrets := map[string]string{}
+	for _, secret := range agentContainer.Secrets {
+		secrets[*secret.Name] = *secret.ValueFrom
+	}
+	proxyArn := secrets["DD_PROXY_HTTPS"]
+	s.Contains(proxyArn, ":parameter/"+s.testPrefix+"/dd-proxy", "DD_PROXY_HTTPS should be sourced from the SSM parameter")
+	s.Equal(proxyArn, secrets["DD_PROXY_HTTP"], "DD_PROXY_HTTP should be sourced from the SSM parameter")
+
+	// Test Firelens log configuration
+	s.NotNil(agentContainer.LogConfiguration, "Expected a log configuration for datadog-agent")
+	s.NotContains(agentContainer.LogConfiguration.Options, "proxy", "The proxy URL should not be rendered in plaintext")
+	logSecrets := map[string]string{}
+	for _, secret := range agentContainer.LogConfiguration.SecretOptions {
+		logSecrets[*secret.Name] = *secret.ValueFrom
+	}
+	s.Equal(proxyArn, logSecrets["proxy"], "The log router proxy should be sourced from the SSM parameter")
+
+	// Test the rendered execution role policy
+	var policy PolicyDocument
+	err = json.Unmarshal([]byte(task["dd_secret_access_policy"]), &policy)
+	s.NoError(err, "Failed to parse the Datadog secret access policy")
+
+	ssmStatement, found := GetPolicyStatement(policy, "ssm:GetParameters")
+	s.True(found, "ssm:GetParameters statement not found in policy")
+	s.Equal(StringOrSlice{proxyArn}, ssmStatement.Resource, "SSM access should be scoped to the proxy parameter")
+}
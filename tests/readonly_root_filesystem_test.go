@@ -0,0 +1,71 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// TestReadonlyRootFilesystem tests that the Datadog sidecars run with a read-only root filesystem
+func (s *ECSFargateSuite) TestReadonlyRootFilesystem() {
+	log.Println("TestReadonlyRootFilesystem: Running test...")
+
+	// Retrieve the task output for the "readonly-root-filesystem" module
+	var containers []types.ContainerDefinition
+	task := terraform.OutputMap(s.T(), s.terraformOptions, "readonly-root-filesystem")
+	s.Equal(s.testPrefix+"-readonly-root-filesystem", task["family"], "Unexpected task family name")
+
+	err := json.Unmarshal([]byte(task["container_definitions"]), &containers)
+	s.NoError(err, "Failed to parse container definitions")
+	s.Equal(5, len(containers), "Expected 5 containers in the task definition")
+
+	// Test Agent Container
+	agentContainer, found := GetContainer(containers, "datadog-agent")
+	s.True(found, "Container datadog-agent not found in definitions")
+	s.Equal(aws.Bool(true), agentContainer.ReadonlyRootFilesystem, "datadog-agent should have a read-only root filesystem")
+	AssertMountPoint(s.T(), agentContainer, MountDdSocket)
+	for volume, path := range map[string]string{
+		"dd-agent-config": "/etc/datadog-agent",
+		"dd-agent-run":    "/opt/datadog-agent/run",
+		"dd-agent-tmp":    "/tmp",
+		"dd-agent-logs":   "/var/log/datadog",
+		"dd-agent-s6":     "/var/run/s6",
+	} {
+		AssertMountPoint(s.T(), agentContainer, types.MountPoint{SourceVolume: aws.String(volume), ContainerPath: aws.String(path), ReadOnly: aws.Bool(false)})
+	}
+	s.Contains(agentContainer.DependsOn, types.ContainerDependency{ContainerName: aws.String("datadog-init"), Condition: types.ContainerConditionSuccess}, "datadog-agent should wait for its configuration to be seeded")
+
+	// Test Init Container seeds the Agent configuration directory
+	initContainer, found := GetContainer(containers, "datadog-init")
+	s.True(found, "Container datadog-init not found in definitions")
+	s.Equal(aws.Bool(true), initContainer.ReadonlyRootFilesystem, "datadog-init should have a read-only root filesystem")
+	s.Equal([]string{"cp -a /etc/datadog-agent/. /opt/datadog-agent-config/"}, initContainer.Command, "Unexpected command for datadog-init")
+	AssertMountPoint(s.T(), initContainer, types.MountPoint{SourceVolume: aws.String("dd-agent-config"), ContainerPath: aws.String("/opt/datadog-agent-config"), ReadOnly: aws.Bool(false)})
+
+	// Test Log Router Container
+	logRouterContainer, found := GetContainer(containers, "datadog-log-router")
+	s.True(found, "Container datadog-log-router not found in definitions")
+	s.Equal(aws.Bool(true), logRouterContainer.ReadonlyRootFilesystem, "datadog-log-router should have a read-only root filesystem")
+	s.Nil(logRouterContainer.User, "datadog-log-router should not run as root")
+	AssertMountPoint(s.T(), logRouterContainer, types.MountPoint{SourceVolume: aws.String("dd-log-router-state"), ContainerPath: aws.String("/var/fluent-bit/state"), ReadOnly: aws.Bool(false)})
+
+	// Test CWS init container
+	cwsInitContainer, found := GetContainer(containers, "cws-instrumentation-init")
+	s.True(found, "Container cws-instrumentation-init not found in definitions")
+	s.Equal(aws.Bool(true), cwsInitContainer.ReadonlyRootFilesystem, "cws-instrumentation-init should have a read-only root filesystem")
+	s.Equal("0", *cwsInitContainer.User, "cws-instrumentation-init requires root to populate the CWS volume")
+	AssertMountPoint(s.T(), cwsInitContainer, MountCWS)
+
+	// Test Application Container is left unchanged
+	appContainer, found := GetContainer(containers, "datadog-apm-app")
+	s.True(found, "Container datadog-apm-app not found in definitions")
+	s.Nil(appContainer.ReadonlyRootFilesystem, "Application container root filesystem should not be changed")
+}
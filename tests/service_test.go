@@ -0,0 +1,68 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestECSFargateService tests the planned ECS services, which are not applied
+func TestECSFargateService(t *testing.T) {
+	log.Println("TestECSFargateService: Running test...")
+
+	plan, testPrefix := planSmokeTest(t, "../smoke_tests/ecs_fargate_service")
+
+	// Test Fargate Launch Type Service
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.dd_task_service.aws_ecs_service.this[0]")
+	service := plan.ResourcePlannedValuesMap["module.dd_task_service.aws_ecs_service.this[0]"].AttributeValues
+	assert.Equal(t, testPrefix+"-service", service["name"], "Service name should default to the task family")
+	assert.Equal(t, testPrefix+"-cluster", service["cluster"], "Unexpected service cluster")
+	assert.Equal(t, float64(2), service["desired_count"], "Unexpected service desired count")
+	assert.Equal(t, "FARGATE", service["launch_type"], "Unexpected service launch type")
+	assert.Equal(t, "SERVICE", service["propagate_tags"], "Service tags should be propagated to the tasks")
+	assert.Equal(t, true, service["enable_ecs_managed_tags"], "ECS managed tags should be enabled")
+	AssertResourceTags(t, map[string]interface{}{
+		"env":     "prod",
+		"service": "test-service",
+		"version": "1.0.0",
+		"team":    "cont-p",
+	}, service["tags"], "Unexpected service tags")
+
+	network := service["network_configuration"].([]interface{})[0].(map[string]interface{})
+	assert.ElementsMatch(t, []interface{}{"subnet-0123456789abcdef0", "subnet-0fedcba9876543210"}, network["subnets"], "Unexpected service subnets")
+	assert.ElementsMatch(t, []interface{}{"sg-0123456789abcdef0"}, network["security_groups"], "Unexpected service security groups")
+	assert.Equal(t, false, network["assign_public_ip"], "Public IP should not be assigned by default")
+
+	circuitBreaker := service["deployment_circuit_breaker"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, true, circuitBreaker["enable"], "Deployment circuit breaker should be enabled by default")
+	assert.Equal(t, true, circuitBreaker["rollback"], "Deployment rollback should be enabled by default")
+
+	// Test Capacity Providers Service
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.dd_task_service_spot.aws_ecs_service.this[0]")
+	spotService := plan.ResourcePlannedValuesMap["module.dd_task_service_spot.aws_ecs_service.this[0]"].AttributeValues
+	assert.Equal(t, testPrefix+"-spot", spotService["name"], "Unexpected service name")
+	assert.Equal(t, float64(1), spotService["desired_count"], "Unexpected service desired count")
+	assert.Empty(t, spotService["launch_type"], "Launch type should not be set with a capacity provider strategy")
+	AssertResourceTags(t, map[string]interface{}{
+		"service": "test-service",
+	}, spotService["tags"], "Unexpected service tags")
+
+	strategies := map[string]map[string]interface{}{}
+	for _, strategy := range spotService["capacity_provider_strategy"].([]interface{}) {
+		strategies[strategy.(map[string]interface{})["capacity_provider"].(string)] = strategy.(map[string]interface{})
+	}
+	assert.Equal(t, float64(3), strategies["FARGATE_SPOT"]["weight"], "Unexpected FARGATE_SPOT weight")
+	assert.Equal(t, float64(1), strategies["FARGATE"]["weight"], "Unexpected FARGATE weight")
+	assert.Equal(t, float64(1), strategies["FARGATE"]["base"], "Unexpected FARGATE base")
+
+	spotCircuitBreaker := spotService["deployment_circuit_breaker"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, true, spotCircuitBreaker["enable"], "Deployment circuit breaker should be enabled by default")
+	assert.Equal(t, false, spotCircuitBreaker["rollback"], "Deployment rollback should be disabled")
+}
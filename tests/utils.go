@@ -6,10 +6,14 @@
 package test
 
 import (
+	"encoding/json"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,11 +22,86 @@ var (
 	MountCWS            = types.MountPoint{SourceVolume: aws.String("cws-instrumentation-volume"), ContainerPath: aws.String("/cws-instrumentation-volume"), ReadOnly: aws.Bool(false)}
 	PortTCP             = types.PortMapping{ContainerPort: aws.Int32(8126), HostPort: aws.Int32(8126), Protocol: types.TransportProtocolTcp}
 	PortUDP             = types.PortMapping{ContainerPort: aws.Int32(8125), HostPort: aws.Int32(8125), Protocol: types.TransportProtocolUdp}
+	PortOTLPGRPC        = types.PortMapping{ContainerPort: aws.Int32(4317), HostPort: aws.Int32(4317), Protocol: types.TransportProtocolTcp}
+	PortOTLPHTTP        = types.PortMapping{ContainerPort: aws.Int32(4318), HostPort: aws.Int32(4318), Protocol: types.TransportProtocolTcp}
 	DependencyAgent     = types.ContainerDependency{ContainerName: aws.String("datadog-agent"), Condition: types.ContainerConditionHealthy}
 	DependencyCWS       = types.ContainerDependency{ContainerName: aws.String("cws-instrumentation-init"), Condition: types.ContainerConditionSuccess}
 	DependencyLogRouter = types.ContainerDependency{ContainerName: aws.String("datadog-log-router"), Condition: types.ContainerConditionHealthy}
 )
 
+// PolicyDocument is a minimal representation of a rendered IAM policy document
+type PolicyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+// PolicyStatement is a minimal representation of a rendered IAM policy statement
+type PolicyStatement struct {
+	Effect    string                              `json:"Effect"`
+	Action    StringOrSlice                       `json:"Action"`
+	Resource  StringOrSlice                       `json:"Resource"`
+	Condition map[string]map[string]StringOrSlice `json:"Condition"`
+}
+
+// StringOrSlice is an IAM policy value that is rendered either as a string or as a list of strings
+type StringOrSlice []string
+
+// UnmarshalJSON decodes both the string and the list of strings forms
+func (s *StringOrSlice) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*s = []string{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*s = multiple
+	return nil
+}
+
+// GetPolicyStatement retrieves the first policy statement allowing the given action
+func GetPolicyStatement(policy PolicyDocument, action string) (PolicyStatement, bool) {
+	for _, statement := range policy.Statement {
+		for _, statementAction := range statement.Action {
+			if statementAction == action {
+				return statement, true
+			}
+		}
+	}
+	return PolicyStatement{}, false
+}
+
+// ImageReference is a parsed container image reference
+type ImageReference struct {
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseImageReference splits a container image reference into its repository, tag and digest
+func ParseImageReference(image string) ImageReference {
+	var ref ImageReference
+	if i := strings.Index(image, "@"); i >= 0 {
+		image, ref.Digest = image[:i], image[i+1:]
+	}
+	// A colon before the last slash belongs to the registry host port
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image, ref.Tag = image[:i], image[i+1:]
+	}
+	ref.Repository = image
+	return ref
+}
+
+// AssertImageDigest checks that a container image is pinned by the expected digest
+func AssertImageDigest(t *testing.T, container types.ContainerDefinition, expectedRepository string, expectedDigest string) {
+	ref := ParseImageReference(*container.Image)
+	assert.Equal(t, expectedRepository, ref.Repository, "Unexpected image repository for %s", *container.Name)
+	assert.Empty(t, ref.Tag, "Image of %s should not reference a mutable tag", *container.Name)
+	assert.Equal(t, expectedDigest, ref.Digest, "Unexpected image digest for %s", *container.Name)
+}
+
 // GetContainer retrieves a container definition by name
 func GetContainer(containers []types.ContainerDefinition, name string) (types.ContainerDefinition, bool) {
 	for _, container := range containers {
@@ -118,3 +197,38 @@ func AssertContainerDependency(t *testing.T, container types.ContainerDefinition
 	assert.True(t, found, "Expected dependency (container:%s, condition:%s) not found in %s container",
 		*expectedDependency.ContainerName, expectedDependency.Condition, *container.Name)
 }
+
+// AssertResourceTags checks the resource tags, ignoring the value of the module version tag which changes with each release
+func AssertResourceTags(t *testing.T, expectedTags map[string]interface{}, tags interface{}, msg string) {
+	resourceTags, ok := tags.(map[string]interface{})
+	assert.True(t, ok, "%s: tags must be a map", msg)
+
+	otherTags := map[string]interface{}{}
+	for key, value := range resourceTags {
+		if key != "dd_ecs_terraform_module" {
+			otherTags[key] = value
+		}
+	}
+	assert.NotEmpty(t, resourceTags["dd_ecs_terraform_module"], "%s: missing module version tag", msg)
+	assert.Equal(t, expectedTags, otherTags, msg)
+}
+
+// planSmokeTest plans a plan-only smoke_tests directory and returns the plan with the test prefix of its resources
+func planSmokeTest(t *testing.T, terraformDir string) (*terraform.PlanStruct, string) {
+	testPrefix := "terraform-test"
+	ciJobID := os.Getenv("CI_JOB_ID")
+	if ciJobID != "" {
+		testPrefix = testPrefix + "-" + ciJobID
+	}
+
+	terraformOptions := &terraform.Options{
+		TerraformDir: terraformDir,
+		Vars: map[string]interface{}{
+			"dd_api_key":  "test-api-key",
+			"dd_service":  "test-service",
+			"dd_site":     "datadoghq.com",
+			"test_prefix": testPrefix,
+		},
+	}
+	return terraform.InitAndPlanAndShowWithStruct(t, terraformOptions), testPrefix
+}
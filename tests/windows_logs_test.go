@@ -0,0 +1,42 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"log"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWindowsLogs tests the planned Windows log group and its Datadog Forwarder subscription, which are not applied
+func TestWindowsLogs(t *testing.T) {
+	log.Println("TestWindowsLogs: Running test...")
+
+	plan, testPrefix := planSmokeTest(t, "../smoke_tests/ecs_fargate_windows_logs")
+
+	// Test Windows Log Group Subscription to the Datadog Forwarder
+	forwarderArn := "arn:aws:lambda:us-east-1:123456789012:function:datadog-forwarder"
+	logGroupName := "/ecs/" + testPrefix + "-windows-forwarder"
+
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.dd_task_windows_forwarder.aws_cloudwatch_log_subscription_filter.dd_forwarder[0]")
+	subscriptionFilter := plan.ResourcePlannedValuesMap["module.dd_task_windows_forwarder.aws_cloudwatch_log_subscription_filter.dd_forwarder[0]"].AttributeValues
+	assert.Equal(t, testPrefix+"-windows-forwarder-datadog-forwarder", subscriptionFilter["name"], "Unexpected subscription filter name")
+	assert.Equal(t, logGroupName, subscriptionFilter["log_group_name"], "Unexpected subscribed log group")
+	assert.Equal(t, forwarderArn, subscriptionFilter["destination_arn"], "The log group should be forwarded to the Datadog Forwarder")
+	assert.Equal(t, "-DEBUG", subscriptionFilter["filter_pattern"], "Unexpected subscription filter pattern")
+
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.dd_task_windows_forwarder.aws_lambda_permission.dd_forwarder[0]")
+	permission := plan.ResourcePlannedValuesMap["module.dd_task_windows_forwarder.aws_lambda_permission.dd_forwarder[0]"].AttributeValues
+	assert.Equal(t, "lambda:InvokeFunction", permission["action"], "Unexpected Lambda permission action")
+	assert.Equal(t, forwarderArn, permission["function_name"], "The permission should be granted on the Datadog Forwarder")
+	assert.Equal(t, "logs.us-east-1.amazonaws.com", permission["principal"], "CloudWatch Logs of the provider region should invoke the Datadog Forwarder")
+
+	terraform.RequirePlannedValuesMapKeyExists(t, plan, "module.dd_task_windows_forwarder.aws_cloudwatch_log_group.dd_awslogs[0]")
+	logGroup := plan.ResourcePlannedValuesMap["module.dd_task_windows_forwarder.aws_cloudwatch_log_group.dd_awslogs[0]"].AttributeValues
+	assert.Equal(t, logGroupName, logGroup["name"], "Unexpected log group name")
+}
@@ -0,0 +1,71 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2025-present Datadog, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// TestWindowsNamedPipes tests the task definition for Windows with APM and DogStatsD over named pipes
+func (s *ECSFargateSuite) TestWindowsNamedPipes() {
+	log.Println("TestWindowsNamedPipes: Running test...")
+
+	// Retrieve the task output for the "windows-named-pipes" module
+	var containers []types.ContainerDefinition
+	task := terraform.OutputMap(s.T(), s.terraformOptions, "windows-named-pipes")
+	s.Equal(s.testPrefix+"-windows-named-pipes", task["family"], "Unexpected task family name")
+
+	err := json.Unmarshal([]byte(task["container_definitions"]), &containers)
+	s.NoError(err, "Failed to parse container definitions")
+	s.Equal(3, len(containers), "Expected 3 containers in the task definition")
+
+	// Test Agent Container
+	agentContainer, found := GetContainer(containers, "datadog-agent")
+	s.True(found, "Container datadog-agent not found in definitions")
+
+	// Verify agent named pipes environment variables
+	expectedAgentEnvVars := map[string]string{
+		"DD_API_KEY":               "test-api-key",
+		"DD_SITE":                  "datadoghq.com",
+		"DD_SERVICE":               "test-service",
+		"ECS_FARGATE":              "true",
+		"DD_APM_WINDOWS_PIPE_NAME": "datadog-apm",
+		"DD_DOGSTATSD_PIPE_NAME":   "datadog-dogstatsd",
+	}
+	AssertEnvVars(s.T(), agentContainer, expectedAgentEnvVars)
+
+	// Verify no mount points (Windows doesn't support sockets)
+	s.Equal(0, len(agentContainer.MountPoints), "Expected no mount points for datadog-agent in Windows")
+
+	// Test Application Containers
+	expectedAppEnvVars := map[string]string{
+		"DD_SERVICE":             "test-service",
+		"DD_TRACE_PIPE_NAME":     "datadog-apm",
+		"DD_DOGSTATSD_PIPE_NAME": "datadog-dogstatsd",
+	}
+
+	// Verify application containers don't fall back to sockets or TCP/UDP
+	unexpectedAppEnvVars := []string{
+		"DD_AGENT_HOST",
+		"DD_DOGSTATSD_URL",
+		"DD_TRACE_AGENT_URL",
+	}
+
+	for _, name := range []string{"datadog-dogstatsd-app", "datadog-apm-app"} {
+		appContainer, found := GetContainer(containers, name)
+		s.True(found, "Container %s not found in definitions", name)
+		AssertEnvVars(s.T(), appContainer, expectedAppEnvVars)
+		AssertNotEnvVars(s.T(), appContainer, unexpectedAppEnvVars)
+		s.Equal(0, len(appContainer.MountPoints), "Expected no mount points for %s in Windows", name)
+	}
+
+	// Verify no volumes at task definition level
+	s.Equal(0, len(task["volumes"]), "Expected no volumes in Windows tasks")
+}